@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"power/internal/logic"
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func exportBillingHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.BillingExportRequest
+		if err := httpx.Parse(r, &req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+			return
+		}
+
+		// 导出逻辑直接向响应写入文件内容，成功时不再返回 JSON
+		l := logic.NewExportBillingLogic(r.Context(), svcCtx, w)
+		if err := l.ExportBilling(&req); err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		}
+	}
+}
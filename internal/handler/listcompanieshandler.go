@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"net/http"
+
+	"power/internal/logic"
+	"power/internal/svc"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+func listCompaniesHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logic.NewListCompaniesLogic(r.Context(), svcCtx)
+		resp, err := l.ListCompanies()
+		if err != nil {
+			httpx.ErrorCtx(r.Context(), w, err)
+		} else {
+			httpx.OkJsonCtx(r.Context(), w, resp)
+		}
+	}
+}
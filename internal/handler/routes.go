@@ -12,16 +12,171 @@ import (
 func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 	server.AddRoutes(
 		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/analysis/duration",
+				Handler: loadDurationHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/billing/basic-charge",
+				Handler: basicChargeAdviceHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/billing/comparison",
+				Handler: billingComparisonHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/billing/export",
+				Handler: exportBillingHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodPost,
 				Path:    "/capacity/",
 				Handler: calculateCapacityHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/capacity/demand",
+				Handler: demandManagementHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/capacity/optimize",
+				Handler: optimizeWindowsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/companies",
+				Handler: listCompaniesHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/companies/coverage",
+				Handler: companyCoverageHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/curve/heatmap",
+				Handler: heatmapHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/curve/typical",
+				Handler: typicalCurveHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/economics/analysis",
+				Handler: economicAnalysisHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/economics/sizing",
+				Handler: sizingCurveHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/export/data",
+				Handler: exportDataHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/methods",
+				Handler: listMethodsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/products",
+				Handler: listProductsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/products",
+				Handler: createProductHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/products/:id",
+				Handler: deleteProductHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/products/:id",
+				Handler: getProductHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPut,
+				Path:    "/products/:id",
+				Handler: updateProductHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/products/rank",
+				Handler: rankProductsHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodGet,
 				Path:    "/query/",
 				Handler: queryDataHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/scenarios",
+				Handler: listScenariosHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/scenarios/:id",
+				Handler: deleteScenarioHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/scenarios/:id",
+				Handler: getScenarioHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/scenarios/:id/clone",
+				Handler: cloneScenarioHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/scenarios/compare",
+				Handler: compareScenariosHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/simulation/dispatch",
+				Handler: simulateDispatchHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/tariffs",
+				Handler: listTariffsHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/tariffs",
+				Handler: createTariffHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/tariffs/:id",
+				Handler: deleteTariffHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/tariffs/:id",
+				Handler: getTariffHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPut,
+				Path:    "/tariffs/:id",
+				Handler: updateTariffHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodPost,
 				Path:    "/upload/",
@@ -0,0 +1,102 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type BasicChargeAdviceLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewBasicChargeAdviceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BasicChargeAdviceLogic {
+	return &BasicChargeAdviceLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *BasicChargeAdviceLogic) BasicChargeAdvice(req *types.BillingRequest) (*types.BasicChargeAdviceResponse, error) {
+	l.Logger.Infof("Comparing basic charge modes: company=%s, startTime=%s, endTime=%s, tariff=%d, transformerCapacity=%v",
+		req.Company, req.StartTime, req.EndTime, req.TariffId, req.TransformerCapacity)
+
+	if req.TransformerCapacity <= 0 {
+		return nil, fmt.Errorf("transformerCapacity is required to compare basic charge modes")
+	}
+	inputs, err := loadBillingInputs(l.ctx, l.svcCtx, req)
+	if err != nil {
+		return nil, err
+	}
+	capacityRule := basicChargeRule{
+		mode:                basicChargeCapacity,
+		capacityPrice:       inputs.tariff.CapacityPrice,
+		transformerCapacity: req.TransformerCapacity,
+	}
+	demandRule := basicChargeRule{
+		mode:                basicChargeDemand,
+		demandPrice:         inputs.tariff.DemandPrice,
+		transformerCapacity: req.TransformerCapacity,
+	}
+	if capacityRule.capacityPrice <= 0 || demandRule.demandPrice <= 0 {
+		return nil, fmt.Errorf("tariff %d needs both a demand price and a capacity price", req.TariffId)
+	}
+
+	resp := &types.BasicChargeAdviceResponse{
+		TransformerCapacity: req.TransformerCapacity,
+		DemandPrice:         demandRule.demandPrice,
+		CapacityPrice:       capacityRule.capacityPrice,
+		MinimumDemand:       demandRule.billedDemand(0),
+		HasStorage:          inputs.hasStorage,
+	}
+	baseline := groupDemandMonths(inputs.baseline)
+	// 储能调度后的负荷按月份与无储能时对应
+	storage := make(map[string]demandMonth)
+	if inputs.hasStorage {
+		for _, month := range groupDemandMonths(inputs.storage) {
+			storage[month.month] = month
+		}
+	}
+	for _, month := range baseline {
+		result := types.BasicChargeMonth{
+			Month:          month.month,
+			MaxDemand:      month.peak,
+			BilledDemand:   demandRule.billedDemand(month.peak),
+			DemandCharge:   demandRule.charge(month.peak),
+			CapacityCharge: capacityRule.charge(0),
+		}
+		result.Recommendation, result.Savings = cheaperBasicCharge(result.CapacityCharge, result.DemandCharge)
+		if inputs.hasStorage {
+			shaved, ok := storage[month.month]
+			if !ok {
+				return nil, fmt.Errorf("no dispatched load for month %s", month.month)
+			}
+			result.StorageMaxDemand = shaved.peak
+			result.StorageBilledDemand = demandRule.billedDemand(shaved.peak)
+			result.StorageDemandCharge = demandRule.charge(shaved.peak)
+			result.StorageRecommendation, result.StorageSavings = cheaperBasicCharge(result.CapacityCharge, result.StorageDemandCharge)
+			resp.StorageBestMonthlyTotal += math.Min(result.CapacityCharge, result.StorageDemandCharge)
+		}
+		resp.Months = append(resp.Months, result)
+		resp.CapacityTotal += result.CapacityCharge
+		resp.DemandTotal += result.DemandCharge
+		resp.StorageDemandTotal += result.StorageDemandCharge
+		resp.BestMonthlyTotal += math.Min(result.CapacityCharge, result.DemandCharge)
+	}
+	resp.Recommendation, _ = cheaperBasicCharge(resp.CapacityTotal, resp.DemandTotal)
+	if inputs.hasStorage {
+		resp.StorageRecommendation, _ = cheaperBasicCharge(resp.CapacityTotal, resp.StorageDemandTotal)
+	}
+
+	l.Logger.Infof("Basic charge over %d months: capacity %.2f, demand %.2f, demand with storage %.2f, recommended %s",
+		len(resp.Months), resp.CapacityTotal, resp.DemandTotal, resp.StorageDemandTotal, resp.Recommendation)
+	return resp, nil
+}
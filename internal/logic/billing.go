@@ -0,0 +1,297 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+)
+
+// 基本电费计收方式
+const (
+	basicChargeDemand   = "demand"   // 按每月最大需量
+	basicChargeCapacity = "capacity" // 按变压器容量
+)
+
+// 按需量计收时，计费需量不低于变压器容量的 40%
+const minimumDemandRatio = 0.4
+
+// 储能调度策略
+const (
+	dispatchTariff      = "tariff"       // 按分时电价：低谷充电，高峰和尖峰放电
+	dispatchSchedule    = "schedule"     // 按指定的充放电时段
+	dispatchPeakShaving = "peak_shaving" // 按目标需量削峰
+)
+
+// 电费明细中各电价时段的排列顺序
+var tariffKinds = []string{tariffCritical, tariffPeak, tariffFlat, tariffValley}
+
+// 电价时段在导出文件中的中文名称
+var tariffKindLabels = map[string]string{
+	tariffCritical: "尖峰",
+	tariffPeak:     "高峰",
+	tariffFlat:     "平段",
+	tariffValley:   "低谷",
+}
+
+// 基本电费规则
+type basicChargeRule struct {
+	mode                string
+	demandPrice         float64 // 需量电价 (元/kW·月)
+	capacityPrice       float64 // 容量电价 (元/kVA·月)
+	transformerCapacity float64 // 变压器容量 (kVA)
+}
+
+// 按需量计收时的计费需量 (kW)
+func (r basicChargeRule) billedDemand(maxDemand float64) float64 {
+	return math.Max(maxDemand, r.transformerCapacity*minimumDemandRatio)
+}
+
+// 一个月的基本电费，按需量计收时与储能调度相关，按容量计收时固定
+func (r basicChargeRule) charge(maxDemand float64) float64 {
+	if r.mode == basicChargeCapacity {
+		return r.transformerCapacity * r.capacityPrice
+	}
+	return demandCharge(r.billedDemand(maxDemand), r.demandPrice)
+}
+
+// 一个自然月的电费明细
+type monthlyBill struct {
+	month        string
+	energy       map[string]float64 // 各电价时段的用电量 (kWh)
+	energyCharge map[string]float64 // 各电价时段的电度电费 (元)
+	maxDemand    float64            // 最大 15 分钟需量 (kW)
+	basicCharge  float64            // 基本电费 (元)
+}
+
+func (b monthlyBill) totalEnergy() float64 {
+	var total float64
+	for _, energy := range b.energy {
+		total += energy
+	}
+	return total
+}
+
+func (b monthlyBill) totalEnergyCharge() float64 {
+	var total float64
+	for _, charge := range b.energyCharge {
+		total += charge
+	}
+	return total
+}
+
+// 电费合计 = 电度电费 + 基本电费
+func (b monthlyBill) total() float64 {
+	return b.totalEnergyCharge() + b.basicCharge
+}
+
+// 按电网侧负荷逐时刻计价并按自然月汇总，负荷需按时间升序排列
+func monthlyBills(points []loadPoint, schedules [12]tariffSchedule, rule basicChargeRule) []monthlyBill {
+	slotHours := 1 / float64(slotsPerHour)
+	var bills []monthlyBill
+	for _, point := range points {
+		month := point.time.Format(monthLayout)
+		if len(bills) == 0 || bills[len(bills)-1].month != month {
+			bills = append(bills, monthlyBill{
+				month:        month,
+				energy:       make(map[string]float64),
+				energyCharge: make(map[string]float64),
+			})
+		}
+		bill := &bills[len(bills)-1]
+		schedule := schedules[point.time.Month()-1]
+		slot := slotOfDay(point.time)
+		bill.energy[schedule.kinds[slot]] += point.load * slotHours
+		bill.energyCharge[schedule.kinds[slot]] += point.load * slotHours * schedule.prices[slot]
+		if point.load > bill.maxDemand {
+			bill.maxDemand = point.load
+		}
+	}
+	for i := range bills {
+		bills[i].basicCharge = rule.charge(bills[i].maxDemand)
+	}
+	return bills
+}
+
+// 储能调度后的电网侧负荷
+func netLoadPoints(slots []dispatchSlot) []loadPoint {
+	points := make([]loadPoint, len(slots))
+	for i, slot := range slots {
+		points[i] = loadPoint{time: slot.time, load: slot.netLoad}
+	}
+	return points
+}
+
+// 转换为接口返回格式，各电价时段按尖峰、高峰、平段、低谷排列
+func billResponse(bill monthlyBill) types.MonthlyBill {
+	resp := types.MonthlyBill{
+		Month:        bill.month,
+		Items:        make([]types.BillItem, 0, len(tariffKinds)),
+		Energy:       bill.totalEnergy(),
+		EnergyCharge: bill.totalEnergyCharge(),
+		MaxDemand:    bill.maxDemand,
+		BasicCharge:  bill.basicCharge,
+		Total:        bill.total(),
+	}
+	for _, kind := range tariffKinds {
+		if energy, ok := bill.energy[kind]; ok {
+			resp.Items = append(resp.Items, types.BillItem{
+				Kind:   kind,
+				Energy: energy,
+				Charge: bill.energyCharge[kind],
+			})
+		}
+	}
+	return resp
+}
+
+// 按请求生成储能系统和调度策略，未指定产品和储能系统参数时返回 false
+func billingDispatch(ctx context.Context, svcCtx *svc.ServiceContext, req *types.BillingRequest, schedules [12]tariffSchedule) (batteryModel, dispatchStrategy, bool, error) {
+	var battery batteryModel
+	switch {
+	case req.ProductId != 0:
+		if req.CabinetCount <= 0 {
+			return batteryModel{}, nil, false, fmt.Errorf("cabinetCount must be positive when productId is given")
+		}
+		product, err := findProduct(ctx, svcCtx, req.ProductId)
+		if err != nil {
+			return batteryModel{}, nil, false, err
+		}
+		spec, err := productCabinetSpec(product, req.Cabinet)
+		if err != nil {
+			return batteryModel{}, nil, false, err
+		}
+		battery = spec.battery(req.CabinetCount)
+	case req.Battery.EnergyCapacity > 0:
+		var err error
+		battery, err = batteryFromConfig(req.Battery)
+		if err != nil {
+			return batteryModel{}, nil, false, err
+		}
+	default:
+		return batteryModel{}, nil, false, nil
+	}
+
+	switch req.Strategy {
+	case dispatchSchedule:
+		strategy, err := newScheduleStrategy(req.Periods)
+		if err != nil {
+			return batteryModel{}, nil, false, err
+		}
+		return battery, strategy, true, nil
+	case dispatchPeakShaving:
+		if req.TargetDemand <= 0 {
+			return batteryModel{}, nil, false, fmt.Errorf("targetDemand must be positive for the peak_shaving strategy")
+		}
+		return battery, peakShavingStrategy{target: req.TargetDemand}, true, nil
+	case dispatchTariff:
+		return battery, tariffStrategy{schedules: schedules}, true, nil
+	default:
+		return batteryModel{}, nil, false, fmt.Errorf("unsupported dispatch strategy: %s", req.Strategy)
+	}
+}
+
+// 出具电费所需的电价、负荷及储能调度后的负荷
+type billingInputs struct {
+	tariff     *model.Tariff
+	schedules  [12]tariffSchedule
+	baseline   []loadPoint // 无储能时的电网侧负荷
+	storage    []loadPoint // 储能调度后的电网侧负荷，未配置储能时为空
+	hasStorage bool
+}
+
+// 查询电价和负荷，配置储能时按调度策略模拟储能动作
+func loadBillingInputs(ctx context.Context, svcCtx *svc.ServiceContext, req *types.BillingRequest) (*billingInputs, error) {
+	tariff, periods, err := findTariff(ctx, svcCtx, req.TariffId)
+	if err != nil {
+		return nil, err
+	}
+	startTime, endTime, err := parseTimeRange(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTariffEffective(tariff, startTime, endTime); err != nil {
+		return nil, err
+	}
+	schedules, err := tariffSchedules(tariffPeriodsFromModel(periods))
+	if err != nil {
+		return nil, fmt.Errorf("tariff %d is invalid: %v", req.TariffId, err)
+	}
+	battery, strategy, hasStorage, err := billingDispatch(ctx, svcCtx, req, schedules)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := queryPowerSeries(ctx, svcCtx, req.StartTime, req.EndTime, req.Company)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no power data for company %s between %s and %s", req.Company, req.StartTime, req.EndTime)
+	}
+
+	inputs := &billingInputs{
+		tariff:     tariff,
+		schedules:  schedules,
+		baseline:   loadPoints(data, req.MeterMultiplier),
+		hasStorage: hasStorage,
+	}
+	if hasStorage {
+		slots := simulateDispatch(battery, inputs.baseline, strategy, req.TransformerCapacity*req.PowerFactor)
+		inputs.storage = netLoadPoints(slots)
+	}
+	return inputs, nil
+}
+
+// 计算每月的基线电费和储能调度后的电费
+func simulateBilling(ctx context.Context, svcCtx *svc.ServiceContext, req *types.BillingRequest) (*types.BillingResponse, error) {
+	if req.BasicChargeMode == basicChargeCapacity && req.TransformerCapacity <= 0 {
+		return nil, fmt.Errorf("transformerCapacity is required when the basic charge is based on capacity")
+	}
+	inputs, err := loadBillingInputs(ctx, svcCtx, req)
+	if err != nil {
+		return nil, err
+	}
+	rule := basicChargeRule{
+		mode:                req.BasicChargeMode,
+		demandPrice:         inputs.tariff.DemandPrice,
+		capacityPrice:       inputs.tariff.CapacityPrice,
+		transformerCapacity: req.TransformerCapacity,
+	}
+
+	resp := &types.BillingResponse{
+		BasicChargeMode: rule.mode,
+		HasStorage:      inputs.hasStorage,
+	}
+	baseline := monthlyBills(inputs.baseline, inputs.schedules, rule)
+	var withStorage []monthlyBill
+	if inputs.hasStorage {
+		withStorage = monthlyBills(inputs.storage, inputs.schedules, rule)
+	}
+	for i, bill := range baseline {
+		comparison := types.BillComparison{
+			Month:    bill.month,
+			Baseline: billResponse(bill),
+		}
+		resp.BaselineTotal += bill.total()
+		if inputs.hasStorage {
+			comparison.WithStorage = billResponse(withStorage[i])
+			comparison.Savings = bill.total() - withStorage[i].total()
+			resp.StorageTotal += withStorage[i].total()
+			resp.TotalSavings += comparison.Savings
+		}
+		resp.Months = append(resp.Months, comparison)
+	}
+	return resp, nil
+}
+
+// 较便宜的基本电费计收方式及其比另一种方式节省的金额，金额相同时按需量计收
+func cheaperBasicCharge(capacityCharge, demandCharge float64) (string, float64) {
+	if capacityCharge < demandCharge {
+		return basicChargeCapacity, demandCharge - capacityCharge
+	}
+	return basicChargeDemand, capacityCharge - demandCharge
+}
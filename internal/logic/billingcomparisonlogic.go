@@ -0,0 +1,38 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type BillingComparisonLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewBillingComparisonLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BillingComparisonLogic {
+	return &BillingComparisonLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *BillingComparisonLogic) BillingComparison(req *types.BillingRequest) (*types.BillingResponse, error) {
+	l.Logger.Infof("Simulating bills: company=%s, startTime=%s, endTime=%s, tariff=%d, basicChargeMode=%s, strategy=%s",
+		req.Company, req.StartTime, req.EndTime, req.TariffId, req.BasicChargeMode, req.Strategy)
+
+	resp, err := simulateBilling(l.ctx, l.svcCtx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Logger.Infof("Simulated %d monthly bills: baseline %.2f, with storage %.2f, savings %.2f",
+		len(resp.Months), resp.BaselineTotal, resp.StorageTotal, resp.TotalSavings)
+	return resp, nil
+}
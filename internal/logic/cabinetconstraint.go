@@ -0,0 +1,69 @@
+package logic
+
+import "math"
+
+// 决定时段储能柜台数的约束
+const (
+	constraintEnergy              = "energy"
+	constraintPcsPower            = "pcs_power"
+	constraintTransformerHeadroom = "transformer_headroom"
+	constraintLoad                = "load"
+)
+
+// 按电量和功率约束计算时段内的储能柜台数，返回台数及起作用的约束
+// amount 为时段充放电量 (kWh)，cabinetEnergy 为单台储能柜的可用电量 (kWh)，
+// slotLimits 为每个 15 分钟时刻储能系统整体允许的功率 (kW)：充电时为变压器余量，放电时为负荷
+func (c cabinetSpec) periodCabinets(amount, cabinetEnergy, hours float64, slotLimits []float64, charge bool) (float64, string) {
+	if cabinetEnergy <= 0 {
+		return 0, constraintEnergy
+	}
+	// PCS 功率不足以在时段内充满或放空时，单台储能柜只能充放 PCS 功率 × 时长的电量
+	energy, constraint := cabinetEnergy, constraintEnergy
+	if c.pcsPower > 0 && c.pcsPower*hours < energy {
+		energy, constraint = c.pcsPower*hours, constraintPcsPower
+	}
+	cabinets := amount / energy
+	if c.pcsPower <= 0 || len(slotLimits) == 0 {
+		return cabinets, constraint
+	}
+
+	// 逐时刻校验：储能系统功率不能超过每个时刻的变压器余量或负荷
+	if fleet := maxFleetSize(c.pcsPower, energy, slotLimits); fleet < cabinets {
+		cabinets, constraint = fleet, constraintLoad
+		if charge {
+			constraint = constraintTransformerHeadroom
+		}
+	}
+	return cabinets, constraint
+}
+
+// 在每个时刻功率不超过 slotLimits 的前提下，能够在时段内充满或放空的最大储能柜台数
+// n 台储能柜在时段内可充放的电量为 Σ min(n × pcsPower, limit) / 4，需不少于 n × energy；
+// 该函数关于 n 为凹函数，满足条件的 n 构成从 0 开始的区间，用二分查找其上界
+func maxFleetSize(pcsPower, energy float64, slotLimits []float64) float64 {
+	fleetEnergy := func(n float64) float64 {
+		var total float64
+		for _, limit := range slotLimits {
+			total += math.Min(n*pcsPower, math.Max(limit, 0)) / slotsPerHour
+		}
+		return total
+	}
+
+	// 数据点少于时段时长时，单台储能柜最多只能按实际时刻数充放电
+	energy = math.Min(energy, pcsPower*float64(len(slotLimits))/slotsPerHour)
+	var total float64
+	for _, limit := range slotLimits {
+		total += math.Max(limit, 0) / slotsPerHour
+	}
+	low, high := 0.0, total/energy
+	for i := 0; i < 60; i++ {
+		mid := (low + high) / 2
+		// 留出浮点误差，功率恰好用满时视为满足
+		if fleetEnergy(mid) >= mid*energy*(1-1e-9) {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
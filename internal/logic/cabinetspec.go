@@ -0,0 +1,161 @@
+package logic
+
+import (
+	"fmt"
+	"math"
+
+	"power/internal/types"
+	"power/model"
+)
+
+// 储能柜参数，未填写的效率、放电深度等参数优先取产品参数，否则取默认值，默认值与只按额定容量测算的结果一致
+type cabinetSpec struct {
+	chargeCapacity      float64 // 额定充电容量 (kWh)
+	dischargeCapacity   float64 // 额定放电容量 (kWh)
+	roundTripEfficiency float64 // 电池往返效率 (0-1)
+	pcsEfficiency       float64 // PCS 单向转换效率 (0-1)
+	depthOfDischarge    float64 // 可用放电深度 (0-1)，荷电状态在 [1-DoD, 1] 之间运行
+	initialSoc          float64 // 首个充电时段开始时的荷电状态
+	endSoc              float64 // 最后一个放电时段结束时的荷电状态
+	auxiliaryPower      float64 // 辅助用电功率 (kW)，空调、BMS 等
+	pcsPower            float64 // PCS 额定功率 (kW)，为 0 时不校验功率约束
+}
+
+// 按请求和产品（可为 nil）组装储能柜参数并校验取值范围
+func newCabinetSpec(req *types.CapacityConfigRequest, product *model.StorageProduct) (cabinetSpec, error) {
+	defaults := model.StorageProduct{
+		RoundTripEfficiency: 1,
+		PcsEfficiency:       1,
+		DepthOfDischarge:    1,
+	}
+	if product != nil {
+		defaults = *product
+	}
+	spec := cabinetSpec{
+		chargeCapacity:      defaultIfZero(req.ChargeCapacity, defaults.EnergyCapacity),
+		dischargeCapacity:   defaultIfZero(req.DischargeCapacity, defaults.EnergyCapacity),
+		roundTripEfficiency: defaultIfZero(req.RoundTripEfficiency, defaults.RoundTripEfficiency),
+		pcsEfficiency:       defaultIfZero(req.PcsEfficiency, defaults.PcsEfficiency),
+		depthOfDischarge:    defaultIfZero(req.DepthOfDischarge, defaults.DepthOfDischarge),
+		initialSoc:          req.InitialSoc,
+		endSoc:              req.EndSoc,
+		auxiliaryPower:      defaultIfZero(req.AuxiliaryPower, defaults.AuxiliaryPower),
+		pcsPower:            defaultIfZero(req.PcsPower, defaults.PcsPower),
+	}
+	return spec, spec.validate()
+}
+
+func (c cabinetSpec) validate() error {
+	if c.chargeCapacity <= 0 || c.dischargeCapacity <= 0 {
+		return fmt.Errorf("cabinet charge and discharge capacity must be positive")
+	}
+	for name, value := range map[string]float64{
+		"roundTripEfficiency": c.roundTripEfficiency,
+		"pcsEfficiency":       c.pcsEfficiency,
+		"depthOfDischarge":    c.depthOfDischarge,
+	} {
+		if value <= 0 || value > 1 {
+			return fmt.Errorf("%s must be in (0, 1], got %v", name, value)
+		}
+	}
+	if c.initialSoc < 0 || c.initialSoc > 1 || c.endSoc < 0 || c.endSoc > 1 {
+		return fmt.Errorf("initialSoc and endSoc must be in [0, 1]")
+	}
+	if c.auxiliaryPower < 0 {
+		return fmt.Errorf("auxiliaryPower must not be negative")
+	}
+	if c.pcsPower < 0 {
+		return fmt.Errorf("pcsPower must not be negative")
+	}
+	return nil
+}
+
+// 将实际使用的储能柜参数填入请求，保存的方案不再依赖产品参数
+func (c cabinetSpec) fill(req *types.CapacityConfigRequest) {
+	req.ChargeCapacity = c.chargeCapacity
+	req.DischargeCapacity = c.dischargeCapacity
+	req.RoundTripEfficiency = c.roundTripEfficiency
+	req.PcsEfficiency = c.pcsEfficiency
+	req.DepthOfDischarge = c.depthOfDischarge
+	req.InitialSoc = c.initialSoc
+	req.EndSoc = c.endSoc
+	req.AuxiliaryPower = c.auxiliaryPower
+	req.PcsPower = c.pcsPower
+}
+
+// 与 fill 相同，转换为调度模拟类接口的 cabinet 参数
+func (c cabinetSpec) params() types.CabinetParams {
+	return types.CabinetParams{
+		DischargeCapacity:   c.dischargeCapacity,
+		RoundTripEfficiency: c.roundTripEfficiency,
+		PcsEfficiency:       c.pcsEfficiency,
+		DepthOfDischarge:    c.depthOfDischarge,
+		InitialSoc:          c.initialSoc,
+		AuxiliaryPower:      c.auxiliaryPower,
+		PcsPower:            c.pcsPower,
+	}
+}
+
+// 最低荷电状态
+func (c cabinetSpec) minSoc() float64 {
+	return 1 - c.depthOfDischarge
+}
+
+// 充电或放电方向的综合效率：电池单向效率乘以 PCS 效率
+func (c cabinetSpec) oneWayEfficiency() float64 {
+	return math.Sqrt(c.roundTripEfficiency) * c.pcsEfficiency
+}
+
+// 单台储能柜在一个充电时段内从电网吸收的电量 (kWh)
+// 首个充电时段从 initialSoc 开始充电，其余充电时段从最低荷电状态开始；辅助用电同样由电网供给
+func (c cabinetSpec) chargeEnergy(hours float64, firstCharge bool) float64 {
+	startSoc := c.minSoc()
+	if firstCharge {
+		startSoc = math.Max(c.initialSoc, startSoc)
+	}
+	return c.chargeCapacity*(1-startSoc)/c.oneWayEfficiency() + c.auxiliaryPower*hours
+}
+
+// 单台储能柜在一个放电时段内向负荷提供的电量 (kWh)
+// 最后一个放电时段放电至 endSoc，其余放电时段放电至最低荷电状态；辅助用电从放电量中扣除
+func (c cabinetSpec) dischargeEnergy(hours float64, lastDischarge bool) float64 {
+	endSoc := c.minSoc()
+	if lastDischarge {
+		endSoc = math.Max(c.endSoc, endSoc)
+	}
+	return math.Max(c.dischargeCapacity*(1-endSoc)*c.oneWayEfficiency()-c.auxiliaryPower*hours, 0)
+}
+
+// 按台数组装调度模拟使用的储能系统，PCS 双向损耗计入往返效率；未设置 PCS 功率时按 1C 充放电
+func (c cabinetSpec) battery(count int) batteryModel {
+	power := defaultIfZero(c.pcsPower, c.dischargeCapacity)
+	return batteryModel{
+		energy:              float64(count) * c.dischargeCapacity,
+		power:               float64(count) * power,
+		minSoc:              c.minSoc(),
+		maxSoc:              1,
+		initialSoc:          math.Max(c.initialSoc, c.minSoc()),
+		roundTripEfficiency: c.roundTripEfficiency * c.pcsEfficiency * c.pcsEfficiency,
+		auxiliaryPower:      float64(count) * c.auxiliaryPower,
+	}
+}
+
+// 按产品和 cabinet 参数组装储能柜参数，供调度模拟类接口使用，与 /capacity/ 的台数测算取值一致
+func productCabinetSpec(product *model.StorageProduct, params types.CabinetParams) (cabinetSpec, error) {
+	return newCabinetSpec(&types.CapacityConfigRequest{
+		DischargeCapacity:   params.DischargeCapacity,
+		RoundTripEfficiency: params.RoundTripEfficiency,
+		PcsEfficiency:       params.PcsEfficiency,
+		DepthOfDischarge:    params.DepthOfDischarge,
+		InitialSoc:          params.InitialSoc,
+		AuxiliaryPower:      params.AuxiliaryPower,
+		PcsPower:            params.PcsPower,
+	}, product)
+}
+
+func defaultIfZero(value, defaultValue float64) float64 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,223 @@
+package logic
+
+import (
+	"math"
+	"testing"
+
+	"power/internal/types"
+	"power/model"
+)
+
+// 单向效率 sqrt(0.81) × 1 = 0.9，最低荷电状态 0.1
+var testCabinet = cabinetSpec{
+	chargeCapacity:      200,
+	dischargeCapacity:   200,
+	roundTripEfficiency: 0.81,
+	pcsEfficiency:       1,
+	depthOfDischarge:    0.9,
+	initialSoc:          0.5,
+	endSoc:              0.2,
+	auxiliaryPower:      2,
+	pcsPower:            100,
+}
+
+func TestCabinetSpecEnergy(t *testing.T) {
+	lowPcs := testCabinet
+	lowPcs.pcsEfficiency = 0.5
+	heavyAux := testCabinet
+	heavyAux.auxiliaryPower = 100
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		// 200 × (1-0.1) / 0.9 + 2 × 4
+		{"charge from min SOC", testCabinet.chargeEnergy(4, false), 208},
+		// 首个充电时段从 initialSoc 0.5 开始：200 × 0.5 / 0.9 + 2 × 4
+		{"first charge from initial SOC", testCabinet.chargeEnergy(4, true), 200*0.5/0.9 + 8},
+		// 单向效率 0.9 × 0.5 = 0.45：200 × 0.9 / 0.45 + 2 × 4
+		{"charge with PCS loss", lowPcs.chargeEnergy(4, false), 408},
+		// 200 × (1-0.1) × 0.9 - 2 × 2
+		{"discharge to min SOC", testCabinet.dischargeEnergy(2, false), 158},
+		// 最后一个放电时段放到 endSoc 0.2：200 × 0.8 × 0.9 - 2 × 2
+		{"last discharge to end SOC", testCabinet.dischargeEnergy(2, true), 140},
+		// 辅助用电超过可放电量时为 0
+		{"auxiliary load exceeds discharge", heavyAux.dischargeEnergy(2, false), 0},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestPeriodCabinets(t *testing.T) {
+	noPcs := testCabinet
+	noPcs.pcsPower = 0
+	flat := func(power float64, slots int) []float64 {
+		limits := make([]float64, slots)
+		for i := range limits {
+			limits[i] = power
+		}
+		return limits
+	}
+
+	tests := []struct {
+		name           string
+		spec           cabinetSpec
+		amount         float64
+		cabinetEnergy  float64
+		hours          float64
+		slotLimits     []float64
+		charge         bool
+		wantCabinets   float64
+		wantConstraint string
+	}{
+		{"no usable energy", testCabinet, 1000, 0, 4, nil, false, 0, constraintEnergy},
+		// 未设置 PCS 功率时只按电量：1000 / 200
+		{"energy only", noPcs, 1000, 200, 4, flat(300, 16), false, 5, constraintEnergy},
+		// 1 小时内单台只能充放 100 kW × 1 h：1000 / 100
+		{"pcs power", testCabinet, 1000, 200, 1, nil, false, 10, constraintPcsPower},
+		// 每个时刻余量 300 kW，n 台可充 16 × min(100n, 300) / 4，不少于 200n 时 n ≤ 6，电量只需 5 台
+		{"headroom not binding", testCabinet, 1000, 200, 4, flat(300, 16), true, 5, constraintEnergy},
+		// 电量需要 8 台，余量最多支持 6 台
+		{"transformer headroom", testCabinet, 1600, 200, 4, flat(300, 16), true, 6, constraintTransformerHeadroom},
+		{"load", testCabinet, 1600, 200, 4, flat(300, 16), false, 6, constraintLoad},
+		// 8 个时刻负荷 400 kW、8 个时刻为 0：8 × min(100n, 400) / 4 ≥ 200n 时 n ≤ 4
+		{"uneven load", testCabinet, 1600, 200, 4, append(flat(400, 8), flat(0, 8)...), false, 4, constraintLoad},
+	}
+	for _, tt := range tests {
+		cabinets, constraint := tt.spec.periodCabinets(tt.amount, tt.cabinetEnergy, tt.hours, tt.slotLimits, tt.charge)
+		if math.Abs(cabinets-tt.wantCabinets) > 1e-6 || constraint != tt.wantConstraint {
+			t.Errorf("%s: periodCabinets = (%v, %s), want (%v, %s)", tt.name, cabinets, constraint, tt.wantCabinets, tt.wantConstraint)
+		}
+	}
+}
+
+func TestEvaluatePeriodLoadsDischarge(t *testing.T) {
+	req := &types.CapacityConfigRequest{TransformerCapacity: 2000, PowerFactor: 1, MeterMultiplier: 1}
+	spec := testCabinet
+	spec.pcsPower = 40
+	// 8 个时刻 900 kW、8 个时刻 100 kW，平均 500 kW，4 小时放电 2000 kWh
+	data := make([]types.PowerData, 16)
+	for i := range data {
+		data[i].Power = 900
+		if i >= 8 {
+			data[i].Power = 100
+		}
+	}
+	periods := []capacityPeriod{{name: "discharge", lastDischarge: true}}
+	loads := []periodLoad{{power: 500, hours: 4, data: data}}
+
+	results, count, limiting, ok := evaluatePeriodLoads(req, spec, periods, loads)
+	if !ok {
+		t.Fatal("evaluatePeriodLoads returned false")
+	}
+	result := results[limiting]
+	// 单台放电量 200 × 0.8 × 0.9 - 2 × 4 = 136 kWh，不超过 PCS 的 40 × 4 = 160 kWh，按电量需 2000 / 136 台
+	if result.amount != 2000 || math.Abs(result.cabinetEnergy-136) > 1e-9 {
+		t.Errorf("amount = %v, cabinet energy = %v, want 2000 and 136", result.amount, result.cabinetEnergy)
+	}
+	// 负荷约束：2 × (min(40n, 900) + min(40n, 100)) ≥ 136n，40n 在 100 和 900 之间时 n ≤ 200 / 56
+	if want := 200.0 / 56; math.Abs(result.cabinets-want) > 1e-6 || result.constraint != constraintLoad || count != 3 {
+		t.Errorf("cabinets = (%v, %s, %d), want (%v, %s, 3)", result.cabinets, result.constraint, count, want, constraintLoad)
+	}
+}
+
+func TestNewCabinetSpec(t *testing.T) {
+	product := &model.StorageProduct{EnergyCapacity: 261, PcsPower: 125, RoundTripEfficiency: 0.92, PcsEfficiency: 0.97, DepthOfDischarge: 0.9, AuxiliaryPower: 1.8}
+
+	spec, err := newCabinetSpec(&types.CapacityConfigRequest{DepthOfDischarge: 0.8, InitialSoc: 0.3}, product)
+	if err != nil {
+		t.Fatalf("newCabinetSpec failed: %v", err)
+	}
+	want := cabinetSpec{
+		chargeCapacity:      261,
+		dischargeCapacity:   261,
+		roundTripEfficiency: 0.92,
+		pcsEfficiency:       0.97,
+		depthOfDischarge:    0.8,
+		initialSoc:          0.3,
+		auxiliaryPower:      1.8,
+		pcsPower:            125,
+	}
+	if spec != want {
+		t.Errorf("newCabinetSpec = %+v, want %+v", spec, want)
+	}
+
+	// 未指定产品时效率和放电深度默认为 1
+	spec, err = newCabinetSpec(&types.CapacityConfigRequest{ChargeCapacity: 100, DischargeCapacity: 100}, nil)
+	if err != nil {
+		t.Fatalf("newCabinetSpec without product failed: %v", err)
+	}
+	if spec.roundTripEfficiency != 1 || spec.pcsEfficiency != 1 || spec.depthOfDischarge != 1 || spec.pcsPower != 0 {
+		t.Errorf("newCabinetSpec without product = %+v, want unit efficiencies and no PCS limit", spec)
+	}
+
+	for _, req := range []types.CapacityConfigRequest{
+		{DepthOfDischarge: 1.2},
+		{PcsEfficiency: -0.5},
+		{InitialSoc: 1.5},
+		{AuxiliaryPower: -1},
+		{PcsPower: -10},
+	} {
+		if _, err := newCabinetSpec(&req, product); err == nil {
+			t.Errorf("newCabinetSpec(%+v) succeeded, want error", req)
+		}
+	}
+	if _, err := newCabinetSpec(&types.CapacityConfigRequest{}, nil); err == nil {
+		t.Error("newCabinetSpec without capacity or product succeeded, want error")
+	}
+}
+
+func TestCabinetSpecBattery(t *testing.T) {
+	spec := testCabinet
+	spec.initialSoc = 0
+	spec.pcsEfficiency = 0.95
+	battery := spec.battery(3)
+	want := batteryModel{
+		energy:              600,
+		power:               300,
+		minSoc:              0.1,
+		maxSoc:              1,
+		initialSoc:          0.1, // 低于最低荷电状态时取最低荷电状态
+		roundTripEfficiency: 0.81 * 0.95 * 0.95,
+		auxiliaryPower:      6,
+	}
+	if math.Abs(battery.minSoc-want.minSoc) > 1e-9 || math.Abs(battery.initialSoc-want.initialSoc) > 1e-9 ||
+		math.Abs(battery.roundTripEfficiency-want.roundTripEfficiency) > 1e-9 {
+		t.Errorf("battery = %+v, want %+v", battery, want)
+	}
+	battery.minSoc, battery.initialSoc, battery.roundTripEfficiency = want.minSoc, want.initialSoc, want.roundTripEfficiency
+	if battery != want {
+		t.Errorf("battery = %+v, want %+v", battery, want)
+	}
+
+	// 未设置 PCS 功率时按 1C 充放电
+	spec.pcsPower = 0
+	if battery := spec.battery(2); battery.power != 400 {
+		t.Errorf("battery power without PCS = %v, want 400", battery.power)
+	}
+}
+
+func TestEvaluatePeriodLoadsOverload(t *testing.T) {
+	req := &types.CapacityConfigRequest{TransformerCapacity: 1000, PowerFactor: 1, MeterMultiplier: 1, OverloadFactor: 1.2}
+	spec := testCabinet
+	spec.initialSoc = 0
+	data := make([]types.PowerData, 16)
+	for i := range data {
+		data[i].Power = 200
+	}
+	periods := []capacityPeriod{{name: "charge", charge: true, firstCharge: true}}
+	loads := []periodLoad{{power: 200, hours: 4, data: data}}
+
+	results, count, _, ok := evaluatePeriodLoads(req, spec, periods, loads)
+	if !ok {
+		t.Fatal("evaluatePeriodLoads returned false")
+	}
+	// 允许负荷 1000 × 1.2 = 1200 kW，4 小时可充 (1200 - 200) × 4 = 4000 kWh，单台 208 kWh，可配置 19 台
+	if results[0].amount != 4000 || count != 19 {
+		t.Errorf("amount = %v, cabinet count = %d, want 4000 and 19", results[0].amount, count)
+	}
+}
@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"sort"
 	"time"
 
 	"power/internal/svc"
 	"power/internal/types"
+	"power/model"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
@@ -27,248 +27,426 @@ func NewCalculateCapacityLogic(ctx context.Context, svcCtx *svc.ServiceContext)
 	}
 }
 
-func (l *CalculateCapacityLogic) CalculateCapacity(req *types.CapacityConfigRequest) (resp *types.CapacityConfigResponse, err error) {
-	l.Logger.Info("Starting capacity calculation for company: ", req.Company)
+const (
+	periodCharge    = "charge"
+	periodDischarge = "discharge"
+)
 
-	// 调用查询逻辑来获取功率数据
-	queryLogic := NewQueryDataLogic(l.ctx, l.svcCtx)
+// 测算时段：充电或放电，起止时间为完整日期时间，按日测算时为当天时刻 (HH:MM)
+type capacityPeriod struct {
+	name   string
+	charge bool
+	start  string
+	end    string
+	// 是否为首个充电时段或最后一个放电时段，决定储能柜的起止荷电状态
+	firstCharge   bool
+	lastDischarge bool
+}
+
+// 时段的统计功率 (kW)、时长 (h) 和逐时刻功率数据
+type periodLoad struct {
+	power float64
+	hours float64
+	data  []types.PowerData
+}
+
+// 时段的统计功率、时长、充放电量 (kWh)、单台储能柜的可用电量 (kWh)、按该时段计算的储能柜台数及决定台数的约束
+type periodResult struct {
+	periodLoad
+	amount        float64
+	cabinetEnergy float64
+	cabinets      float64
+	constraint    string
+	backflowLoss  float64
+}
 
-	// 第一次充电时段
-	firstChargePower, err := l.getPower(queryLogic, req.FirstChargePeriod[0], req.FirstChargePeriod[1], req.Company, req.CalculationMethod)
+func (l *CalculateCapacityLogic) CalculateCapacity(req *types.CapacityConfigRequest) (*types.CapacityConfigResponse, error) {
+	resolved, err := l.resolveRequest(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query power data for first charge period: %v", err)
+		return nil, err
 	}
-	firstChargeHours := l.getHours(req.FirstChargePeriod[0], req.FirstChargePeriod[1], firstChargePower)
-
-	// 第一次放电时段
-	firstDischargePower, err := l.getPower(queryLogic, req.FirstDischargePeriod[0], req.FirstDischargePeriod[1], req.Company, req.CalculationMethod)
+	resp, err := l.calculate(resolved)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query power data for first discharge period: %v", err)
+		return nil, err
 	}
-	firstDischargeHours := l.getHours(req.FirstDischargePeriod[0], req.FirstDischargePeriod[1], firstDischargePower)
+	resp.ScenarioId = recordScenario(l.ctx, l.svcCtx, capacityScenario(scenarioCapacity, resolved, resp))
+	return resp, nil
+}
 
-	// 第二次充电时段
-	secondChargePower, err := l.getPower(queryLogic, req.SecondChargePeriod[0], req.SecondChargePeriod[1], req.Company, req.CalculationMethod)
+// 填入按电价生成的充放电时段和实际使用的储能柜参数，保存的方案重新测算时不受电价和产品修改的影响
+func (l *CalculateCapacityLogic) resolveRequest(req *types.CapacityConfigRequest) (*types.CapacityConfigRequest, error) {
+	resolved := *req
+	if len(req.Periods) == 0 && len(req.Seasons) == 0 && req.TariffId != 0 {
+		derived, err := l.tariffDerivedRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		resolved = *derived
+	}
+	spec, err := cabinetSpecFor(l.ctx, l.svcCtx, &resolved)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query power data for second charge period: %v", err)
+		return nil, err
 	}
-	secondChargeHours := l.getHours(req.SecondChargePeriod[0], req.SecondChargePeriod[1], secondChargePower)
+	spec.fill(&resolved)
+	return &resolved, nil
+}
+
+// 测算储能柜台数，不保存方案，供其他测算和重新测算方案时调用
+func (l *CalculateCapacityLogic) calculate(req *types.CapacityConfigRequest) (resp *types.CapacityConfigResponse, err error) {
+	l.Logger.Info("Starting capacity calculation for company: ", req.Company)
 
-	// 第二次放电时段
-	secondDischargePower, err := l.getPower(queryLogic, req.SecondDischargePeriod[0], req.SecondDischargePeriod[1], req.Company, req.CalculationMethod)
+	// 未填写充放电时段时按分时电价生成，未指定月份时按电价分季
+	if len(req.Periods) == 0 && len(req.Seasons) == 0 && req.TariffId != 0 {
+		if req, err = l.tariffDerivedRequest(req); err != nil {
+			return nil, err
+		}
+	}
+	spec, err := cabinetSpecFor(l.ctx, l.svcCtx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query power data for second discharge period: %v", err)
+		return nil, err
 	}
-	secondDischargeHours := l.getHours(req.SecondDischargePeriod[0], req.SecondDischargePeriod[1], secondDischargePower)
 
-	// 如果任何一个时段的时长为0，说明数据无效，返回0的结果
-	if firstChargeHours == 0 || firstDischargeHours == 0 || secondChargeHours == 0 || secondDischargeHours == 0 {
-		l.Logger.Infof("One or more periods have no valid data, returning 0 for all values")
-		return &types.CapacityConfigResponse{
-			MinCabinetCount:       0,
-			FirstChargeAmount:     0,
-			FirstDischargeAmount:  0,
-			SecondChargeAmount:    0,
-			SecondDischargeAmount: 0,
-		}, nil
+	// 指定日期范围时，各时段按当天时刻在每一天分别测算
+	if req.StartDate != "" || req.EndDate != "" {
+		seasons, err := capacitySeasons(req)
+		if err != nil {
+			return nil, err
+		}
+		return l.calculateDailyCapacity(req, spec, seasons)
+	}
+	if len(req.Seasons) > 0 {
+		return nil, fmt.Errorf("seasons require startDate and endDate")
+	}
+	periods, err := capacityPeriods(req.Periods)
+	if err != nil {
+		return nil, err
 	}
 
-	// 使用查询到的功率数据进行容量计算
-	meterMultiplier := req.MeterMultiplier
-	powerFactor := req.PowerFactor
-	transformerCapacity := req.TransformerCapacity
-
-	// 计算每个时段的充电量和放电量
-	firstChargeAmount := math.Round(transformerCapacity*powerFactor-firstChargePower*meterMultiplier) * firstChargeHours
-	firstDischargeAmount := math.Round(firstDischargePower * meterMultiplier * firstDischargeHours * powerFactor)
+	// 调用查询逻辑来获取功率数据，每个时段只查询一次，敏感性分析的各计算方法共用
+	queryLogic := NewQueryDataLogic(l.ctx, l.svcCtx)
 
-	secondChargeAmount := math.Round(transformerCapacity*powerFactor-secondChargePower*meterMultiplier) * secondChargeHours
-	secondDischargeAmount := math.Round(secondDischargePower * meterMultiplier * secondDischargeHours * powerFactor)
+	periodData := make([][]types.PowerData, len(periods))
+	for i, period := range periods {
+		periodData[i], err = l.getPeriodData(queryLogic, period.start, period.end, req.Company)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query power data for %s period: %v", period.name, err)
+		}
+	}
 
-	// 打印每个时段的充放电量
-	l.Logger.Infof("First Charge Amount: %f kWh", firstChargeAmount)
-	l.Logger.Infof("First Discharge Amount: %f kWh", firstDischargeAmount)
-	l.Logger.Infof("Second Charge Amount: %f kWh", secondChargeAmount)
-	l.Logger.Infof("Second Discharge Amount: %f kWh", secondDischargeAmount)
+	resp, err = l.capacityForMethod(req, spec, periods, periodData, req.CalculationMethod)
+	if err != nil {
+		return nil, err
+	}
+	if req.Sensitivity {
+		resp.Sensitivity, err = sensitivityRows(req, func(method string) (*types.CapacityConfigResponse, error) {
+			return l.capacityForMethod(req, spec, periods, periodData, method)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
 
-	// 计算储能柜台数
-	firstChargeCabinets := firstChargeAmount / req.ChargeCapacity
-	firstDischargeCabinets := firstDischargeAmount / req.DischargeCapacity
-	secondChargeCabinets := secondChargeAmount / req.ChargeCapacity
-	secondDischargeCabinets := secondDischargeAmount / req.DischargeCapacity
+// 按请求和产品参数组装储能柜参数，并校验与台数测算相关的其他参数
+func cabinetSpecFor(ctx context.Context, svcCtx *svc.ServiceContext, req *types.CapacityConfigRequest) (cabinetSpec, error) {
+	var product *model.StorageProduct
+	if req.ProductId != 0 {
+		var err error
+		product, err = findProduct(ctx, svcCtx, req.ProductId)
+		if err != nil {
+			return cabinetSpec{}, err
+		}
+	}
+	spec, err := newCabinetSpec(req, product)
+	if err != nil {
+		return cabinetSpec{}, err
+	}
+	if req.BackflowMargin < 0 {
+		return cabinetSpec{}, fmt.Errorf("backflowMargin must not be negative")
+	}
+	if req.OverloadFactor != 0 && req.OverloadFactor < 1 {
+		return cabinetSpec{}, fmt.Errorf("overloadFactor must be at least 1, got %v", req.OverloadFactor)
+	}
+	if req.ProposedCabinetCount < 0 {
+		return cabinetSpec{}, fmt.Errorf("proposedCabinetCount must not be negative")
+	}
+	return spec, nil
+}
 
-	// 打印每个时段的储能柜数量
-	l.Logger.Infof("First Charge Cabinets: %f", firstChargeCabinets)
-	l.Logger.Infof("First Discharge Cabinets: %f", firstDischargeCabinets)
-	l.Logger.Infof("Second Charge Cabinets: %f", secondChargeCabinets)
-	l.Logger.Infof("Second Discharge Cabinets: %f", secondDischargeCabinets)
+// 按指定的计算方法根据各时段的功率数据测算储能柜台数
+func (l *CalculateCapacityLogic) capacityForMethod(req *types.CapacityConfigRequest, spec cabinetSpec, periods []capacityPeriod, periodData [][]types.PowerData, method string) (*types.CapacityConfigResponse, error) {
+	loads := make([]periodLoad, len(periods))
+	for i, period := range periods {
+		power, err := l.getPower(periodData[i], method)
+		if err != nil {
+			return nil, err
+		}
+		loads[i] = periodLoad{
+			power: power,
+			hours: l.getHours(period.start, period.end, power),
+			data:  periodData[i],
+		}
+	}
 
-	// 取最小储能柜台数
-	minCabinetCount := int(math.Min(math.Min(firstChargeCabinets, firstDischargeCabinets), math.Min(secondChargeCabinets, secondDischargeCabinets)))
+	results, minCabinetCount, limitingPeriod, ok := l.evaluatePeriods(req, spec, periods, loads)
+	// 如果任何一个时段的时长为0，说明数据无效，返回0的结果
+	if !ok {
+		l.Logger.Infof("One or more periods have no valid data, returning 0 for all values")
+		return &types.CapacityConfigResponse{}, nil
+	}
 
 	// 返回结果
-	resp = &types.CapacityConfigResponse{
-		MinCabinetCount:       minCabinetCount,
-		FirstChargeAmount:     firstChargeAmount,
-		FirstDischargeAmount:  firstDischargeAmount,
-		SecondChargeAmount:    secondChargeAmount,
-		SecondDischargeAmount: secondDischargeAmount,
+	resp := &types.CapacityConfigResponse{
+		MinCabinetCount:   minCabinetCount,
+		Periods:           periodResults(periods, results),
+		LimitingPeriod:    limitingPeriod,
+		BindingConstraint: results[limitingPeriod].constraint,
+		BackflowLoss:      totalBackflowLoss(results),
+	}
+	// 按指定台数或测算出的最小台数逐时刻校验充电时的变压器负荷
+	resp.ProposedCabinetCount = proposedCabinetCount(req, minCabinetCount)
+	resp.OverloadSlots = overloadSlots(req, spec, periods, results, resp.ProposedCabinetCount)
+	if len(resp.OverloadSlots) > 0 {
+		l.Logger.Infof("Transformer overloaded in %d slots with %d cabinets", len(resp.OverloadSlots), resp.ProposedCabinetCount)
 	}
 	return resp, nil
 }
 
-// 获取功率的辅助方法，根据请求的计算方法计算功率
-func (l *CalculateCapacityLogic) getPower(queryLogic *QueryDataLogic, startTime, endTime, company, method string) (float64, error) {
-	queryReq := types.QueryRequest{
-		StartTime: startTime,
-		EndTime:   endTime,
-		Company:   company,
+// 按 tariffId 指定的分时电价生成充放电时段，返回填入时段或分季时段的请求
+func (l *CalculateCapacityLogic) tariffDerivedRequest(req *types.CapacityConfigRequest) (*types.CapacityConfigRequest, error) {
+	if req.StartDate == "" || req.EndDate == "" {
+		return nil, fmt.Errorf("periods derived from a tariff require startDate and endDate")
 	}
-
-	queryResp, err := queryLogic.QueryData(&queryReq)
+	startDate, endDate, err := parseDateRange(req)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-
-	if len(queryResp.Data) == 0 {
-		l.Logger.Infof("No data points available for power calculation")
-		return 0, nil
+	schedules, err := loadTariffSchedules(l.ctx, l.svcCtx, req.TariffId, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	derived, err := deriveTariffPeriods(req, schedules, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive periods from tariff %d: %v", req.TariffId, err)
 	}
+	if len(derived.Seasons) > 0 {
+		l.Logger.Infof("Derived %d seasons from tariff %d", len(derived.Seasons), req.TariffId)
+	} else {
+		l.Logger.Infof("Derived %d periods from tariff %d for month %d", len(derived.Periods), req.TariffId, req.TariffMonth)
+	}
+	return derived, nil
+}
 
-	// 根据请求的方法选择计算功率的方式
-	switch method {
-	case "average":
-		return l.calculateAveragePower(queryResp), nil
-	case "median":
-		return l.calculateMedian(queryResp), nil
-	case "mode":
-		return l.calculateMode(queryResp), nil
-	case "percentile90":
-		return l.calculatePercentile(queryResp, 90), nil
-	case "quartile":
-		return l.calculateQuartile(queryResp), nil
-	case "stddev_mean":
-		return l.calculateStdDevMean(queryResp), nil
-	default:
-		return 0, fmt.Errorf("unsupported calculation method: %s", method)
-		//return l.calculateAveragePower(queryResp), nil
+// 指定 tariffMonth 时所有日期使用该月的时段，否则按日期范围内各月份的时段分季，每天使用所在月份的时段
+func deriveTariffPeriods(req *types.CapacityConfigRequest, schedules [12]tariffSchedule, startDate, endDate time.Time) (*types.CapacityConfigRequest, error) {
+	derived := *req
+	if req.TariffMonth != 0 {
+		month, err := tariffMonth(req)
+		if err != nil {
+			return nil, err
+		}
+		if derived.Periods, err = schedules[month-1].capacityPeriods(); err != nil {
+			return nil, err
+		}
+		return &derived, nil
+	}
+	seasons, err := tariffSeasons(schedules, startDate, endDate)
+	if err != nil {
+		return nil, err
 	}
+	derived.Seasons = seasons
+	return &derived, nil
 }
 
-// 计算平均数
-func (l *CalculateCapacityLogic) calculateAveragePower(queryResp *types.QueryResponse) float64 {
-	var totalPower float64
-	for _, data := range queryResp.Data {
-		totalPower += data.Power
+// 生成充放电时段使用的电价月份，未指定时取开始日期所在月份
+func tariffMonth(req *types.CapacityConfigRequest) (int, error) {
+	month := req.TariffMonth
+	if month == 0 {
+		startDate, err := time.Parse(dateLayout, req.StartDate)
+		if err != nil {
+			return 0, fmt.Errorf("invalid start date %s: %v", req.StartDate, err)
+		}
+		month = int(startDate.Month())
 	}
-	if len(queryResp.Data) == 0 {
-		l.Logger.Infof("No data points available for power calculation")
-		return 0
+	if month < 1 || month > 12 {
+		return 0, fmt.Errorf("invalid tariff month %d", month)
 	}
-	avgPower := totalPower / float64(len(queryResp.Data))
-	l.Logger.Infof("Calculated average power: %.2f", avgPower) // 打印平均功率
-	return avgPower
+	return month, nil
 }
 
-// 计算中位数
-func (l *CalculateCapacityLogic) calculateMedian(queryResp *types.QueryResponse) float64 {
-	var powers []float64
-	for _, data := range queryResp.Data {
-		powers = append(powers, data.Power)
+// 按请求中的时段顺序组装测算时段
+func capacityPeriods(requested []types.CapacityPeriod) ([]capacityPeriod, error) {
+	if len(requested) == 0 {
+		return nil, fmt.Errorf("at least one charge or discharge period is required")
 	}
-	sort.Float64s(powers)
-	length := len(powers)
-	if length == 0 {
-		l.Logger.Infof("No data points available for median calculation")
-		return 0
+	periods := make([]capacityPeriod, len(requested))
+	for i, p := range requested {
+		if p.Kind != periodCharge && p.Kind != periodDischarge {
+			return nil, fmt.Errorf("period %d has unsupported kind: %s", i, p.Kind)
+		}
+		periods[i] = capacityPeriod{
+			name:   fmt.Sprintf("period %d (%s)", i, p.Kind),
+			charge: p.Kind == periodCharge,
+			start:  p.Start,
+			end:    p.End,
+		}
 	}
-	var median float64
-	if length%2 == 0 {
-		median = (powers[length/2-1] + powers[length/2]) / 2
-	} else {
-		median = powers[length/2]
+	for i := range periods {
+		if periods[i].charge {
+			periods[i].firstCharge = true
+			break
+		}
+	}
+	for i := len(periods) - 1; i >= 0; i-- {
+		if !periods[i].charge {
+			periods[i].lastDischarge = true
+			break
+		}
 	}
-	l.Logger.Infof("Calculated median power: %f", median) // 打印中位数
-	return median
+	return periods, nil
 }
 
-// 计算众数
-func (l *CalculateCapacityLogic) calculateMode(queryResp *types.QueryResponse) float64 {
-	frequencyMap := make(map[float64]int)
-	var mode float64
-	maxFrequency := 0
-	for _, data := range queryResp.Data {
-		frequencyMap[data.Power]++
-		if frequencyMap[data.Power] > maxFrequency {
-			mode = data.Power
-			maxFrequency = frequencyMap[data.Power]
+// 根据各时段的统计功率和时长计算充放电量及储能柜台数，返回最小台数及其所在时段序号，任一时段时长为 0 时返回 false
+func (l *CalculateCapacityLogic) evaluatePeriods(req *types.CapacityConfigRequest, spec cabinetSpec, periods []capacityPeriod, loads []periodLoad) ([]periodResult, int, int, bool) {
+	results, minCabinetCount, limitingPeriod, ok := evaluatePeriodLoads(req, spec, periods, loads)
+	// 打印每个时段的充放电量和储能柜数量
+	for i, result := range results {
+		l.Logger.Infof("%s amount: %f kWh, cabinets: %f, constraint: %s", periods[i].name, result.amount, result.cabinets, result.constraint)
+	}
+	return results, minCabinetCount, limitingPeriod, ok
+}
+
+// evaluatePeriods 的计算部分，不输出日志，供需要反复测算的优化器使用
+func evaluatePeriodLoads(req *types.CapacityConfigRequest, spec cabinetSpec, periods []capacityPeriod, loads []periodLoad) ([]periodResult, int, int, bool) {
+	for _, load := range loads {
+		if load.hours == 0 {
+			return nil, 0, 0, false
 		}
 	}
-	if maxFrequency == 0 {
-		l.Logger.Infof("No data points available for mode calculation")
-		return 0
+
+	// 使用查询到的功率数据进行容量计算
+	meterMultiplier := req.MeterMultiplier
+	powerFactor := req.PowerFactor
+	// 充电量和逐时刻余量都按含过载系数的变压器允许负荷计算
+	limit := transformerLimit(req)
+
+	results := make([]periodResult, len(periods))
+	limitingPeriod := 0
+	for i, period := range periods {
+		load := loads[i]
+		results[i].periodLoad = load
+		// 计算每个时段的充电量和放电量，以及单台储能柜考虑效率、放电深度和辅助用电后的可用电量
+		// 同时计算每个时刻储能系统允许的功率：充电时为变压器余量，放电时为负荷
+		slotLimits := make([]float64, len(load.data))
+		if period.charge {
+			results[i].amount = math.Round(limit-load.power*meterMultiplier) * load.hours
+			results[i].cabinetEnergy = spec.chargeEnergy(load.hours, period.firstCharge)
+			for j, d := range load.data {
+				slotLimits[j] = limit - d.Power*meterMultiplier
+			}
+		} else {
+			results[i].amount = math.Round(load.power * meterMultiplier * load.hours * powerFactor)
+			results[i].cabinetEnergy = spec.dischargeEnergy(load.hours, period.lastDischarge)
+			for j, d := range load.data {
+				slotLimits[j] = d.Power * meterMultiplier * powerFactor
+			}
+			// 防逆流模式：每个时刻的放电功率不超过负荷减去安全裕度，按统计功率放电时超出的部分从放电量中扣除
+			if req.AntiBackflow {
+				for j, d := range load.data {
+					allowed := math.Max(d.Power*meterMultiplier-req.BackflowMargin, 0)
+					results[i].backflowLoss += math.Max(load.power*meterMultiplier-allowed, 0) * powerFactor / slotsPerHour
+					slotLimits[j] = allowed * powerFactor
+				}
+				results[i].amount = math.Max(results[i].amount-results[i].backflowLoss, 0)
+			}
+		}
+		// 按电量、PCS 功率和逐时刻功率约束计算储能柜台数，单台可用电量为 0 时该时段无法配置储能柜
+		results[i].cabinets, results[i].constraint = spec.periodCabinets(results[i].amount, results[i].cabinetEnergy, load.hours, slotLimits, period.charge)
+
+		// 取最小储能柜台数
+		if results[i].cabinets < results[limitingPeriod].cabinets {
+			limitingPeriod = i
+		}
 	}
-	l.Logger.Infof("Calculated mode power: %f", mode) // 打印众数
-	return mode
+	return results, int(results[limitingPeriod].cabinets), limitingPeriod, true
 }
 
-// 计算百分位数
-func (l *CalculateCapacityLogic) calculatePercentile(queryResp *types.QueryResponse, percentile float64) float64 {
-	var powers []float64
-	for _, data := range queryResp.Data {
-		powers = append(powers, data.Power)
+// 将时段测算结果转换为接口返回格式
+func periodResults(periods []capacityPeriod, results []periodResult) []types.PeriodResult {
+	resp := make([]types.PeriodResult, len(periods))
+	for i, period := range periods {
+		kind := periodDischarge
+		if period.charge {
+			kind = periodCharge
+		}
+		resp[i] = types.PeriodResult{
+			Kind:          kind,
+			Start:         period.start,
+			End:           period.end,
+			Power:         results[i].power,
+			Hours:         results[i].hours,
+			Amount:        results[i].amount,
+			CabinetEnergy: results[i].cabinetEnergy,
+			Cabinets:      results[i].cabinets,
+			Constraint:    results[i].constraint,
+			BackflowLoss:  results[i].backflowLoss,
+		}
 	}
-	sort.Float64s(powers)
-	length := len(powers)
-	if length == 0 {
-		l.Logger.Infof("No data points available for percentile calculation")
-		return 0
+	return resp
+}
+
+// 校验变压器过载使用的储能柜台数，未指定时取测算出的最小台数
+func proposedCabinetCount(req *types.CapacityConfigRequest, minCabinetCount int) int {
+	if req.ProposedCabinetCount > 0 {
+		return req.ProposedCabinetCount
 	}
-	index := percentile / 100 * float64(length-1)
-	lower := int(math.Floor(index))
-	upper := int(math.Ceil(index))
-	var percentileValue float64
-	if lower == upper {
-		percentileValue = powers[lower]
-	} else {
-		percentileValue = powers[lower] + (powers[upper]-powers[lower])*(index-float64(lower))
+	return minCabinetCount
+}
+
+// 各时段防逆流少放电量之和
+func totalBackflowLoss(results []periodResult) float64 {
+	var loss float64
+	for _, result := range results {
+		loss += result.backflowLoss
 	}
-	l.Logger.Infof("Calculated %f percentile power: %f", percentile, percentileValue) // 打印百分位数
-	return percentileValue
+	return loss
 }
 
-// 计算四分位数（Q1、Q2、Q3的平均值）
-func (l *CalculateCapacityLogic) calculateQuartile(queryResp *types.QueryResponse) float64 {
-	q1 := l.calculatePercentile(queryResp, 25)
-	q2 := l.calculatePercentile(queryResp, 50) // 中位数
-	q3 := l.calculatePercentile(queryResp, 75)
-	quartileAverage := (q1 + q2 + q3) / 3
-	l.Logger.Infof("Calculated Quartile Average (Q1, Q2, Q3): %f", quartileAverage) // 打印四分位数平均值
-	return quartileAverage
+// 查询时段内的功率数据，跨零点的时段结束时间顺延到次日
+func (l *CalculateCapacityLogic) getPeriodData(queryLogic *QueryDataLogic, startTime, endTime, company string) ([]types.PowerData, error) {
+	queryReq := types.QueryRequest{
+		StartTime: startTime,
+		EndTime:   wrapPeriodEnd(startTime, endTime),
+		Company:   company,
+	}
+
+	queryResp, err := queryLogic.QueryData(&queryReq)
+	if err != nil {
+		return nil, err
+	}
+	return queryResp.Data, nil
 }
 
-// 计算标准差和均值的组合
-func (l *CalculateCapacityLogic) calculateStdDevMean(queryResp *types.QueryResponse) float64 {
+// 获取功率的辅助方法，根据请求的计算方法计算功率
+func (l *CalculateCapacityLogic) getPower(data []types.PowerData, method string) (float64, error) {
+	if len(data) == 0 {
+		l.Logger.Infof("No data points available for power calculation")
+		return 0, nil
+	}
+
 	var powers []float64
-	var sum, mean, variance float64
-	for _, data := range queryResp.Data {
-		powers = append(powers, data.Power)
-		sum += data.Power
-	}
-	length := len(powers)
-	if length == 0 {
-		l.Logger.Infof("No data points available for standard deviation calculation")
-		return 0
+	for _, d := range data {
+		powers = append(powers, d.Power)
 	}
-	mean = sum / float64(length)
-	for _, power := range powers {
-		variance += math.Pow(power-mean, 2)
+
+	// 根据请求的方法选择计算功率的方式
+	power, err := calculateStatistic(method, powers)
+	if err != nil {
+		return 0, err
 	}
-	stddev := math.Sqrt(variance / float64(length))
-	// 返回均值加标准差
-	stddevMean := mean + stddev
-	l.Logger.Infof("Calculated Mean + Standard Deviation: %f", stddevMean) // 打印均值+标准差
-	return stddevMean
+	l.Logger.Infof("Calculated %s power: %f", method, power) // 打印统计功率
+	return power, nil
 }
 
 // 计算两个时间点之间的时长（小时）
@@ -291,7 +469,8 @@ func (l *CalculateCapacityLogic) getHours(startTimeStr, endTimeStr string, power
 		l.Logger.Errorf("Invalid end time: %s", endTimeStr)
 		return 0
 	}
-	// 如果结束时间早于开始时间，返回 0
+	// 跨零点的时段，结束时间顺延到次日；顺延后仍早于开始时间则返回 0
+	endTime = wrapEndTime(startTime, endTime)
 	if endTime.Before(startTime) {
 		l.Logger.Errorf("End time is before start time: %s < %s", endTimeStr, startTimeStr)
 		return 0
@@ -0,0 +1,132 @@
+package logic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"power/internal/types"
+)
+
+// 按月份适用的一组测算时段
+type capacitySeason struct {
+	name      string
+	months    []int
+	requested []types.CapacityPeriod // 请求中的时段，用于返回结果
+	periods   []capacityPeriod
+	windows   []clockWindow
+}
+
+// 一次测算中的全部季节，未分季时只有一个覆盖全年的季节
+type seasonSet struct {
+	seasons  []capacitySeason
+	byMonth  [12]int // 每个月份所属季节的下标，不属于任何季节时为 -1
+	seasonal bool    // 是否按 seasons 分季测算，分季时返回各季节的结果
+}
+
+// 日期所在月份适用的季节，不属于任何季节时返回 false
+func (s seasonSet) forDate(date time.Time) (int, bool) {
+	index := s.byMonth[date.Month()-1]
+	return index, index >= 0
+}
+
+// 按请求组装测算季节：填写 seasons 时按季节分组，否则全年使用 periods
+func capacitySeasons(req *types.CapacityConfigRequest) (seasonSet, error) {
+	var set seasonSet
+	if len(req.Seasons) == 0 {
+		season, err := newCapacitySeason("", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, req.Periods)
+		if err != nil {
+			return seasonSet{}, err
+		}
+		set.seasons = []capacitySeason{season}
+		return set, nil
+	}
+	if len(req.Periods) > 0 {
+		return seasonSet{}, fmt.Errorf("periods and seasons cannot be used together")
+	}
+
+	set.seasonal = true
+	for i := range set.byMonth {
+		set.byMonth[i] = -1
+	}
+	for i, s := range req.Seasons {
+		if len(s.Months) == 0 {
+			return seasonSet{}, fmt.Errorf("season %d has no months", i)
+		}
+		for _, month := range s.Months {
+			if month < 1 || month > 12 {
+				return seasonSet{}, fmt.Errorf("season %d has invalid month %d", i, month)
+			}
+			if set.byMonth[month-1] >= 0 {
+				return seasonSet{}, fmt.Errorf("month %d belongs to more than one season", month)
+			}
+			set.byMonth[month-1] = i
+		}
+		season, err := newCapacitySeason(s.Name, s.Months, s.Periods)
+		if err != nil {
+			return seasonSet{}, fmt.Errorf("invalid season %d: %v", i, err)
+		}
+		set.seasons = append(set.seasons, season)
+	}
+	return set, nil
+}
+
+func newCapacitySeason(name string, months []int, requested []types.CapacityPeriod) (capacitySeason, error) {
+	periods, err := capacityPeriods(requested)
+	if err != nil {
+		return capacitySeason{}, err
+	}
+	windows, err := periodWindows(periods)
+	if err != nil {
+		return capacitySeason{}, err
+	}
+	if name == "" {
+		name = monthList(months)
+	}
+	return capacitySeason{
+		name:      name,
+		months:    months,
+		requested: requested,
+		periods:   periods,
+		windows:   windows,
+	}, nil
+}
+
+// 按电价表生成日期范围内各月份的分季时段，生成的充放电时段相同的月份归为一季，季节按首个月份排列
+// 日期范围以外的月份不生成时段，这些月份没有充放电时段也不影响测算
+func tariffSeasons(schedules [12]tariffSchedule, startDate, endDate time.Time) ([]types.CapacitySeason, error) {
+	var inRange [12]bool
+	for date := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, startDate.Location()); !date.After(endDate); date = date.AddDate(0, 1, 0) {
+		inRange[date.Month()-1] = true
+	}
+
+	var seasons []types.CapacitySeason
+	keys := make(map[string]int)
+	for month := 1; month <= 12; month++ {
+		if !inRange[month-1] {
+			continue
+		}
+		periods, err := schedules[month-1].capacityPeriods()
+		if err != nil {
+			return nil, fmt.Errorf("month %d: %v", month, err)
+		}
+		key := fmt.Sprint(periods)
+		if i, ok := keys[key]; ok {
+			seasons[i].Months = append(seasons[i].Months, month)
+			continue
+		}
+		keys[key] = len(seasons)
+		seasons = append(seasons, types.CapacitySeason{Months: []int{month}, Periods: periods})
+	}
+	return seasons, nil
+}
+
+// 月份列表，例如 1,7,8,12
+func monthList(months []int) string {
+	fields := make([]string, len(months))
+	for i, month := range months {
+		fields[i] = strconv.Itoa(month)
+	}
+	return strings.Join(fields, ",")
+}
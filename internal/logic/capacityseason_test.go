@@ -0,0 +1,94 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"power/internal/types"
+)
+
+// 1-6 月和 9-11 月使用通用时段，7-8 月傍晚高峰提前到 16:00，12 月全天平段，没有充放电时段
+func seasonTestSchedules(t *testing.T) [12]tariffSchedule {
+	t.Helper()
+	var periods []types.TariffPeriod
+	for _, p := range optimizerTariffPeriods {
+		p.Months = []int{1, 2, 3, 4, 5, 6, 9, 10, 11}
+		periods = append(periods, p)
+	}
+	periods = append(periods,
+		types.TariffPeriod{Kind: tariffValley, Start: "00:00", End: "08:00", Price: 0.3, Months: []int{7, 8}},
+		types.TariffPeriod{Kind: tariffPeak, Start: "08:00", End: "12:00", Price: 1.2, Months: []int{7, 8}},
+		types.TariffPeriod{Kind: tariffFlat, Start: "12:00", End: "16:00", Price: 0.7, Months: []int{7, 8}},
+		types.TariffPeriod{Kind: tariffPeak, Start: "16:00", End: "22:00", Price: 1.2, Months: []int{7, 8}},
+		types.TariffPeriod{Kind: tariffFlat, Start: "22:00", End: "00:00", Price: 0.7, Months: []int{7, 8}},
+		types.TariffPeriod{Kind: tariffFlat, Start: "00:00", End: "00:00", Price: 0.6, Months: []int{12}},
+	)
+	schedules, err := tariffSchedules(periods)
+	if err != nil {
+		t.Fatalf("tariffSchedules failed: %v", err)
+	}
+	return schedules
+}
+
+func TestTariffSeasonsDateRange(t *testing.T) {
+	schedules := seasonTestSchedules(t)
+	tests := []struct {
+		start, end time.Time
+		wantMonths [][]int
+		wantErr    bool
+	}{
+		// 只生成 6-8 月的时段，12 月没有充放电时段不影响测算
+		{time.Date(2023, 6, 15, 0, 0, 0, 0, time.Local), time.Date(2023, 8, 10, 0, 0, 0, 0, time.Local), [][]int{{6}, {7, 8}}, false},
+		// 跨年的日期范围包含 12 月，该月没有充放电时段
+		{time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local), time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local), nil, true},
+		{time.Date(2023, 9, 1, 0, 0, 0, 0, time.Local), time.Date(2023, 11, 30, 0, 0, 0, 0, time.Local), [][]int{{9, 10, 11}}, false},
+	}
+	for _, tt := range tests {
+		seasons, err := tariffSeasons(schedules, tt.start, tt.end)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("tariffSeasons(%s, %s) error = %v, wantErr %v", tt.start.Format(dateLayout), tt.end.Format(dateLayout), err, tt.wantErr)
+			continue
+		}
+		var months [][]int
+		for _, s := range seasons {
+			months = append(months, s.Months)
+		}
+		if !reflect.DeepEqual(months, tt.wantMonths) {
+			t.Errorf("tariffSeasons(%s, %s) months = %v, want %v", tt.start.Format(dateLayout), tt.end.Format(dateLayout), months, tt.wantMonths)
+		}
+	}
+}
+
+func TestDeriveTariffPeriods(t *testing.T) {
+	schedules := seasonTestSchedules(t)
+	start := time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(2023, 8, 31, 0, 0, 0, 0, time.Local)
+	june, err := schedules[5].capacityPeriods()
+	if err != nil {
+		t.Fatal(err)
+	}
+	july, err := schedules[6].capacityPeriods()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 未指定 tariffMonth 时按月份分季，7-8 月不再使用开始日期所在的 6 月的时段
+	derived, err := deriveTariffPeriods(&types.CapacityConfigRequest{TariffId: 1}, schedules, start, end)
+	if err != nil {
+		t.Fatalf("deriveTariffPeriods failed: %v", err)
+	}
+	want := []types.CapacitySeason{{Months: []int{6}, Periods: june}, {Months: []int{7, 8}, Periods: july}}
+	if len(derived.Periods) != 0 || !reflect.DeepEqual(derived.Seasons, want) {
+		t.Errorf("derived periods = %v, seasons = %v, want seasons %v", derived.Periods, derived.Seasons, want)
+	}
+
+	// 指定 tariffMonth 时所有日期使用该月的时段
+	derived, err = deriveTariffPeriods(&types.CapacityConfigRequest{TariffId: 1, TariffMonth: 7}, schedules, start, end)
+	if err != nil {
+		t.Fatalf("deriveTariffPeriods with tariffMonth failed: %v", err)
+	}
+	if len(derived.Seasons) != 0 || !reflect.DeepEqual(derived.Periods, july) {
+		t.Errorf("derived periods = %v, seasons = %v, want periods %v", derived.Periods, derived.Seasons, july)
+	}
+}
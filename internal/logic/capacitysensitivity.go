@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"fmt"
+
+	"power/internal/types"
+)
+
+// 敏感性分析默认比较的计算方法
+var sensitivityMethods = []string{"average", "median", "mode", "percentile90", "quartile", "stddev_mean"}
+
+// 依次按所有计算方法和请求中的百分位数测算，生成对比表；run 在同一份数据上按指定方法测算
+func sensitivityRows(req *types.CapacityConfigRequest, run func(method string) (*types.CapacityConfigResponse, error)) ([]types.SensitivityRow, error) {
+	methods := append([]string{}, sensitivityMethods...)
+	for _, percentile := range req.Percentiles {
+		if percentile < 0 || percentile > 100 {
+			return nil, fmt.Errorf("percentile must be in [0, 100], got %v", percentile)
+		}
+		methods = append(methods, fmt.Sprintf("percentile:%g", percentile))
+	}
+
+	rows := make([]types.SensitivityRow, 0, len(methods))
+	for _, method := range methods {
+		resp, err := run(method)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate capacity with method %s: %v", method, err)
+		}
+		row := types.SensitivityRow{
+			Method:            method,
+			MinCabinetCount:   resp.MinCabinetCount,
+			LimitingPeriod:    resp.LimitingPeriod,
+			BindingConstraint: resp.BindingConstraint,
+			ConstrainingDay:   resp.ConstrainingDay,
+		}
+		for _, period := range resp.Periods {
+			row.Amounts = append(row.Amounts, period.Amount)
+			row.Cabinets = append(row.Cabinets, period.Cabinets)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CloneScenarioLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCloneScenarioLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CloneScenarioLogic {
+	return &CloneScenarioLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CloneScenarioLogic) CloneScenario(req *types.ScenarioCloneRequest) (*types.Scenario, error) {
+	source, err := findScenario(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 重新测算时按当前数据生成新的结果和数据版本，否则复制原方案的全部内容
+	clone := *source
+	if req.Recalculate {
+		input, err := recalculateScenario(l.ctx, l.svcCtx, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recalculate scenario %d: %v", source.Id, err)
+		}
+		recalculated, err := newScenario(l.ctx, l.svcCtx, input)
+		if err != nil {
+			return nil, err
+		}
+		clone = *recalculated
+	}
+	clone.Id = 0
+	clone.SourceId = source.Id
+	clone.Name = req.Name
+	if clone.Name == "" {
+		clone.Name = source.Name + "（副本）"
+	}
+	clone.Author = req.Author
+	if clone.Author == "" {
+		clone.Author = source.Author
+	}
+
+	id, err := insertScenario(l.ctx, l.svcCtx, &clone)
+	if err != nil {
+		l.Logger.Error("Failed to clone scenario: ", err)
+		return nil, err
+	}
+	l.Logger.Infof("Cloned scenario %d to %d, recalculate=%v", source.Id, id, req.Recalculate)
+	return loadScenario(l.ctx, l.svcCtx, id)
+}
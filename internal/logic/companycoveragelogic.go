@@ -0,0 +1,66 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CompanyCoverageLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCompanyCoverageLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CompanyCoverageLogic {
+	return &CompanyCoverageLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CompanyCoverageLogic) CompanyCoverage(req *types.CoverageRequest) (*types.CoverageResponse, error) {
+	counts, err := l.svcCtx.Model.QueryDailyCounts(l.ctx, req.Company)
+	if err != nil {
+		l.Logger.Error("Database query failed: ", err)
+		return nil, err
+	}
+
+	days := buildCoverageDays(counts)
+	l.Logger.Infof("Company %s has data on %d of %d days", req.Company, len(counts), len(days))
+	return &types.CoverageResponse{
+		Company: req.Company,
+		Days:    days,
+	}, nil
+}
+
+// 将每天的数据时刻数量展开为首尾日期之间的完整日历，无数据的日期数量为 0
+func buildCoverageDays(counts []model.DailyCount) []types.CoverageDay {
+	if len(counts) == 0 {
+		return nil
+	}
+	slotCounts := make(map[string]int, len(counts))
+	for _, c := range counts {
+		slotCounts[c.DataDate.Format(dateLayout)] = int(c.SlotCount)
+	}
+
+	first, last := counts[0].DataDate, counts[len(counts)-1].DataDate
+	start := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+	var days []types.CoverageDay
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		key := date.Format(dateLayout)
+		days = append(days, types.CoverageDay{
+			Date:      key,
+			SlotCount: slotCounts[key],
+			Complete:  slotCounts[key] >= slotsPerDay,
+		})
+	}
+	return days
+}
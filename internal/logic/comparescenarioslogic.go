@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CompareScenariosLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCompareScenariosLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CompareScenariosLogic {
+	return &CompareScenariosLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CompareScenariosLogic) CompareScenarios(req *types.ScenarioCompareRequest) (*types.ScenarioComparison, error) {
+	left, err := findScenario(l.ctx, l.svcCtx, req.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := findScenario(l.ctx, l.svcCtx, req.Right)
+	if err != nil {
+		return nil, err
+	}
+	if left.Kind != right.Kind {
+		return nil, fmt.Errorf("cannot compare a %s scenario with a %s scenario", left.Kind, right.Kind)
+	}
+
+	resp := &types.ScenarioComparison{}
+	for _, side := range []struct {
+		scenario *model.Scenario
+		target   *types.Scenario
+	}{{left, &resp.Left}, {right, &resp.Right}} {
+		version, err := dataVersion(l.ctx, l.svcCtx, side.scenario.Company)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query data version: %v", err)
+		}
+		*side.target = *scenarioFromModel(side.scenario, version)
+	}
+	resp.InputDiffs, err = diffJSON(left.Request, right.Request)
+	if err != nil {
+		return nil, err
+	}
+	resp.ResultDiffs, err = diffJSON(left.Result, right.Result)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
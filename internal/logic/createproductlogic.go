@@ -0,0 +1,50 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateProductLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateProductLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateProductLogic {
+	return &CreateProductLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateProductLogic) CreateProduct(req *types.ProductRequest) (*types.Product, error) {
+	if err := validateProduct(req); err != nil {
+		return nil, err
+	}
+
+	var product model.StorageProduct
+	applyProductRequest(&product, req)
+	result, err := l.svcCtx.ProductModel.Insert(l.ctx, &product)
+	if err != nil {
+		l.Logger.Error("Failed to insert product: ", err)
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	l.Logger.Infof("Created product %d: %s", id, req.Name)
+	created, err := findProduct(l.ctx, l.svcCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	return productFromModel(created), nil
+}
@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateTariffLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateTariffLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateTariffLogic {
+	return &CreateTariffLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateTariffLogic) CreateTariff(req *types.TariffRequest) (*types.Tariff, error) {
+	tariff, periods, err := tariffFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := l.svcCtx.TariffModel.InsertWithPeriods(l.ctx, tariff, periods)
+	if err != nil {
+		l.Logger.Error("Failed to insert tariff: ", err)
+		return nil, err
+	}
+
+	l.Logger.Infof("Created tariff %d: %s", id, req.Name)
+	created, createdPeriods, err := findTariff(l.ctx, l.svcCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	return tariffFromModel(created, createdPeriods), nil
+}
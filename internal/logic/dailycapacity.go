@@ -0,0 +1,220 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+)
+
+// 按日测算：各时段按当天时刻在日期范围内的每一天分别计算充放电量和储能柜台数，分季时每天使用所在月份的时段
+func (l *CalculateCapacityLogic) calculateDailyCapacity(req *types.CapacityConfigRequest, spec cabinetSpec, seasons seasonSet) (*types.CapacityConfigResponse, error) {
+	startDate, endDate, err := parseDateRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 一次查询整个日期范围的数据，再按日期分组；多查询前一天，供跨零点的时段使用
+	data, err := queryDailySeries(l.ctx, l.svcCtx, req.Company, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query power data: %v", err)
+	}
+	days := groupByDay(data)
+
+	resp, err := l.dailyCapacityForMethod(req, spec, seasons, days, startDate, endDate, req.CalculationMethod)
+	if err != nil {
+		return nil, err
+	}
+	if req.Sensitivity {
+		resp.Sensitivity, err = sensitivityRows(req, func(method string) (*types.CapacityConfigResponse, error) {
+			return l.dailyCapacityForMethod(req, spec, seasons, days, startDate, endDate, method)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// 解析按日测算的日期范围
+func parseDateRange(req *types.CapacityConfigRequest) (time.Time, time.Time, error) {
+	location, err := loadLocation()
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to load location: %v", err)
+	}
+	startDate, err := time.ParseInLocation(dateLayout, req.StartDate, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date %s: %v", req.StartDate, err)
+	}
+	endDate, err := time.ParseInLocation(dateLayout, req.EndDate, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date %s: %v", req.EndDate, err)
+	}
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date %s is before start date %s", req.EndDate, req.StartDate)
+	}
+	return startDate, endDate, nil
+}
+
+// 解析各时段的当天时刻
+func periodWindows(periods []capacityPeriod) ([]clockWindow, error) {
+	windows := make([]clockWindow, len(periods))
+	for i, period := range periods {
+		var err error
+		windows[i], err = parseClockWindow(period.start, period.end)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s period: %v", period.name, err)
+		}
+	}
+	return windows, nil
+}
+
+// 查询日期范围内的功率数据，包含开始日期的前一天
+func queryDailySeries(ctx context.Context, svcCtx *svc.ServiceContext, company string, startDate, endDate time.Time) ([]model.PowerData, error) {
+	return queryPowerSeries(ctx, svcCtx, startDate.AddDate(0, 0, -1).Format(dateTimeLayout),
+		endDate.Add(24*time.Hour-time.Second).Format(dateTimeLayout), company)
+}
+
+// 按指定的计算方法在日期范围内的每一天分别测算，储能柜台数取所有日期中的最小值
+func (l *CalculateCapacityLogic) dailyCapacityForMethod(req *types.CapacityConfigRequest, spec cabinetSpec, seasons seasonSet,
+	days map[string]*daySeries, startDate, endDate time.Time, method string) (*types.CapacityConfigResponse, error) {
+	if _, err := calculateStatistic(method, nil); err != nil {
+		return nil, err
+	}
+
+	resp := &types.CapacityConfigResponse{}
+	var cabinetCounts []float64
+	// 保留每天的测算结果及所属季节，确定台数后再逐时刻校验变压器负荷
+	type dayResult struct {
+		season  int
+		results []periodResult
+	}
+	var dayResults []dayResult
+	seasonResults := make([]types.SeasonCapacity, len(seasons.seasons))
+	seasonCounts := make([][]float64, len(seasons.seasons))
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		key := date.Format(dateLayout)
+		index, ok := seasons.forDate(date)
+		if !ok {
+			resp.SkippedDays = append(resp.SkippedDays, key)
+			continue
+		}
+		season := seasons.seasons[index]
+		if _, ok := days[key]; !ok {
+			resp.SkippedDays = append(resp.SkippedDays, key)
+			seasonResults[index].SkippedDays = append(seasonResults[index].SkippedDays, key)
+			continue
+		}
+
+		loads := make([]periodLoad, len(season.periods))
+		for i, window := range season.windows {
+			var err error
+			loads[i], err = windowLoad(window.data(days, date), window, method)
+			if err != nil {
+				return nil, err
+			}
+		}
+		results, cabinetCount, limitingPeriod, ok := l.evaluatePeriods(req, spec, season.periods, loads)
+		if !ok {
+			l.Logger.Infof("One or more periods have no valid data on %s, skipping", key)
+			resp.SkippedDays = append(resp.SkippedDays, key)
+			seasonResults[index].SkippedDays = append(seasonResults[index].SkippedDays, key)
+			continue
+		}
+
+		daily := types.DailyCapacity{
+			Date:              key,
+			MinCabinetCount:   cabinetCount,
+			Periods:           periodResults(season.periods, results),
+			LimitingPeriod:    limitingPeriod,
+			BindingConstraint: results[limitingPeriod].constraint,
+		}
+		resp.Days = append(resp.Days, daily)
+		resp.BackflowLoss += totalBackflowLoss(results)
+		dayResults = append(dayResults, dayResult{season: index, results: results})
+		cabinetCounts = append(cabinetCounts, float64(cabinetCount))
+
+		// 储能柜台数取所有日期中的最小值，保证每一天都能充满放空
+		if resp.ConstrainingDay == "" || cabinetCount < resp.MinCabinetCount {
+			resp.MinCabinetCount = cabinetCount
+			resp.ConstrainingDay = key
+			resp.Periods = daily.Periods
+			resp.LimitingPeriod = limitingPeriod
+			resp.BindingConstraint = daily.BindingConstraint
+		}
+		// 各季节分别记录台数最少的一天
+		seasonCounts[index] = append(seasonCounts[index], float64(cabinetCount))
+		if result := &seasonResults[index]; result.ConstrainingDay == "" || cabinetCount < result.MinCabinetCount {
+			result.MinCabinetCount = cabinetCount
+			result.ConstrainingDay = key
+			result.LimitingPeriod = limitingPeriod
+			result.BindingConstraint = daily.BindingConstraint
+		}
+	}
+
+	if seasons.seasonal {
+		for i, season := range seasons.seasons {
+			result := seasonResults[i]
+			result.Name = season.name
+			result.Months = season.months
+			result.Periods = season.requested
+			if len(seasonCounts[i]) > 0 {
+				result.CabinetSummary = summarizeCabinetCounts(seasonCounts[i])
+			}
+			resp.Seasons = append(resp.Seasons, result)
+		}
+	}
+
+	if len(resp.Days) == 0 {
+		l.Logger.Infof("No day between %s and %s has valid data, returning 0 for all values", req.StartDate, req.EndDate)
+		return resp, nil
+	}
+
+	resp.CabinetSummary = summarizeCabinetCounts(cabinetCounts)
+	resp.ProposedCabinetCount = proposedCabinetCount(req, resp.MinCabinetCount)
+	for _, day := range dayResults {
+		periods := seasons.seasons[day.season].periods
+		resp.OverloadSlots = append(resp.OverloadSlots, overloadSlots(req, spec, periods, day.results, resp.ProposedCabinetCount)...)
+	}
+
+	l.Logger.Infof("Daily capacity: %d days evaluated, %d skipped, min cabinets %d on %s, %d overload slots",
+		len(resp.Days), len(resp.SkippedDays), resp.MinCabinetCount, resp.ConstrainingDay, len(resp.OverloadSlots))
+	return resp, nil
+}
+
+// 计算时段内的统计功率和时长，时长与 getHours 一致按起止时刻之间的数据点数计算，无数据时时长为 0
+func windowLoad(data []types.PowerData, window clockWindow, method string) (periodLoad, error) {
+	if len(data) == 0 {
+		return periodLoad{}, nil
+	}
+	powers := make([]float64, len(data))
+	for i, d := range data {
+		powers[i] = d.Power
+	}
+	power, err := calculateStatistic(method, powers)
+	if err != nil {
+		return periodLoad{}, err
+	}
+	if power == 0 {
+		return periodLoad{}, nil
+	}
+	return periodLoad{
+		power: power,
+		hours: float64(window.slots()) / slotsPerHour,
+		data:  data,
+	}, nil
+}
+
+// 每日储能柜台数的分布
+func summarizeCabinetCounts(counts []float64) types.CabinetSummary {
+	return types.CabinetSummary{
+		Min:    calculatePercentile(counts, 0),
+		P10:    calculatePercentile(counts, 10),
+		Median: calculatePercentile(counts, 50),
+		P90:    calculatePercentile(counts, 90),
+		Max:    calculatePercentile(counts, 100),
+	}
+}
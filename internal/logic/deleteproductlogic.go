@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteProductLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteProductLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteProductLogic {
+	return &DeleteProductLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteProductLogic) DeleteProduct(req *types.ProductIdRequest) (*types.MessageResponse, error) {
+	if _, err := findProduct(l.ctx, l.svcCtx, req.Id); err != nil {
+		return nil, err
+	}
+	if err := l.svcCtx.ProductModel.Delete(l.ctx, req.Id); err != nil {
+		l.Logger.Error("Failed to delete product: ", err)
+		return nil, err
+	}
+
+	l.Logger.Infof("Deleted product %d", req.Id)
+	return &types.MessageResponse{
+		Message: "产品已删除",
+	}, nil
+}
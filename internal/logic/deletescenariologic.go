@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteScenarioLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteScenarioLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteScenarioLogic {
+	return &DeleteScenarioLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteScenarioLogic) DeleteScenario(req *types.ScenarioIdRequest) (*types.MessageResponse, error) {
+	if _, err := findScenario(l.ctx, l.svcCtx, req.Id); err != nil {
+		return nil, err
+	}
+	if err := l.svcCtx.ScenarioModel.Delete(l.ctx, req.Id); err != nil {
+		l.Logger.Error("Failed to delete scenario: ", err)
+		return nil, err
+	}
+
+	l.Logger.Infof("Deleted scenario %d", req.Id)
+	return &types.MessageResponse{
+		Message: "方案已删除",
+	}, nil
+}
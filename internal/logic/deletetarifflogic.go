@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeleteTariffLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteTariffLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteTariffLogic {
+	return &DeleteTariffLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteTariffLogic) DeleteTariff(req *types.TariffIdRequest) (*types.MessageResponse, error) {
+	if _, _, err := findTariff(l.ctx, l.svcCtx, req.Id); err != nil {
+		return nil, err
+	}
+	if err := l.svcCtx.TariffModel.DeleteWithPeriods(l.ctx, req.Id); err != nil {
+		l.Logger.Error("Failed to delete tariff: ", err)
+		return nil, err
+	}
+
+	l.Logger.Infof("Deleted tariff %d", req.Id)
+	return &types.MessageResponse{
+		Message: "电价已删除",
+	}, nil
+}
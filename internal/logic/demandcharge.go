@@ -0,0 +1,111 @@
+package logic
+
+import (
+	"time"
+)
+
+const (
+	maxDemandCabinets = 500  // 按目标需量测算台数时的最大台数
+	demandTolerance   = 0.01 // 判断削峰后需量是否达到目标的容差 (kW)
+)
+
+// 一个自然月的负荷及最大 15 分钟需量
+type demandMonth struct {
+	month    string // YYYY-MM
+	points   []loadPoint
+	days     int     // 有负荷数据的天数
+	peak     float64 // 最大需量 (kW)
+	peakTime time.Time
+}
+
+// 按自然月分组负荷并计算每月的最大需量，负荷需按时间升序排列
+func groupDemandMonths(points []loadPoint) []demandMonth {
+	var months []demandMonth
+	for _, point := range points {
+		month := point.time.Format(monthLayout)
+		if len(months) == 0 || months[len(months)-1].month != month {
+			months = append(months, demandMonth{month: month})
+		}
+		m := &months[len(months)-1]
+		if len(m.points) == 0 || m.points[len(m.points)-1].time.Format(dateLayout) != point.time.Format(dateLayout) {
+			m.days++
+		}
+		m.points = append(m.points, point)
+		if len(m.points) == 1 || point.load > m.peak {
+			m.peak = point.load
+			m.peakTime = point.time
+		}
+	}
+	return months
+}
+
+// 削峰使用的储能系统，每月从满电开始调度
+func demandBattery(spec cabinetSpec, cabinetCount int) batteryModel {
+	battery := spec.battery(cabinetCount)
+	battery.initialSoc = battery.maxSoc
+	return battery
+}
+
+// 按目标需量削峰调度一个月，返回调度后的最大需量及其时刻
+func shavedPeak(battery batteryModel, month demandMonth, target, gridLimit float64) (float64, time.Time) {
+	var peak float64
+	var peakTime time.Time
+	for i, slot := range simulateDispatch(battery, month.points, peakShavingStrategy{target: target}, gridLimit) {
+		if i == 0 || slot.netLoad > peak {
+			peak = slot.netLoad
+			peakTime = slot.time
+		}
+	}
+	return peak, peakTime
+}
+
+// 二分查找储能系统在该月能够守住的最低目标需量
+func lowestTarget(battery batteryModel, month demandMonth, gridLimit float64) float64 {
+	low, high := 0.0, month.peak
+	for high-low > demandTolerance {
+		mid := (low + high) / 2
+		if peak, _ := shavedPeak(battery, month, mid, gridLimit); peak <= mid+demandTolerance {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return high
+}
+
+// 二分查找把每个月的需量都削到 target 以下所需的最少台数，超过 maxDemandCabinets 时返回 false
+func cabinetsForTarget(spec cabinetSpec, months []demandMonth, target, gridLimit float64) (int, bool) {
+	reaches := func(count int) bool {
+		for _, month := range months {
+			if month.peak <= target {
+				continue
+			}
+			if peak, _ := shavedPeak(demandBattery(spec, count), month, target, gridLimit); peak > target+demandTolerance {
+				return false
+			}
+		}
+		return true
+	}
+
+	if reaches(0) {
+		return 0, true
+	}
+	if !reaches(maxDemandCabinets) {
+		return 0, false
+	}
+	low, high := 0, maxDemandCabinets
+	for high-low > 1 {
+		mid := (low + high) / 2
+		if reaches(mid) {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return high, true
+}
+
+// 按需量计收的基本电费 = 最大需量 × 需量电价
+func demandCharge(peak, price float64) float64 {
+	return peak * price
+}
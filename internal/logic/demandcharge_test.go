@@ -0,0 +1,87 @@
+package logic
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// 单台 100 kWh、50 kW，不计损耗和辅助用电
+var demandTestCabinet = cabinetSpec{
+	chargeCapacity:      100,
+	dischargeCapacity:   100,
+	roundTripEfficiency: 1,
+	pcsEfficiency:       1,
+	depthOfDischarge:    1,
+	pcsPower:            50,
+}
+
+// 一天的负荷：基础负荷 100 kW，从 12:00 起 peakSlots 个时刻为 300 kW
+func demandTestMonth(peakSlots int) demandMonth {
+	start := time.Date(2023, 5, 1, 0, 0, 0, 0, time.Local)
+	points := make([]loadPoint, slotsPerDay)
+	for i := range points {
+		points[i] = loadPoint{time: start.Add(time.Duration(i) * slotMinutes * time.Minute), load: 100}
+		if i >= 12*slotsPerHour && i < 12*slotsPerHour+peakSlots {
+			points[i].load = 300
+		}
+	}
+	return groupDemandMonths(points)[0]
+}
+
+func TestCabinetsForTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		peakSlots int
+		target    float64
+		want      int
+	}{
+		// 目标高于最大需量，无需储能
+		{"no shaving", 4, 350, 0},
+		// 1 小时削去 100 kW，需放电 100 kWh，功率约束需 100 / 50 = 2 台
+		{"power", 4, 200, 2},
+		// 2.5 小时削去 100 kW，需放电 250 kWh，电量约束需 3 台
+		{"energy", 10, 200, 3},
+	}
+	for _, tt := range tests {
+		count, ok := cabinetsForTarget(demandTestCabinet, []demandMonth{demandTestMonth(tt.peakSlots)}, tt.target, 10000)
+		if !ok || count != tt.want {
+			t.Errorf("%s: cabinetsForTarget = (%d, %v), want (%d, true)", tt.name, count, ok, tt.want)
+		}
+	}
+}
+
+func TestLowestTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		peakSlots int
+		cabinets  int
+		want      float64
+	}{
+		// 1 台 50 kW，1 小时高峰只能削去 50 kW
+		{"power", 4, 1, 250},
+		// 3 台 150 kW，削到 150 kW 只需放电 150 kWh
+		{"power with spare energy", 4, 3, 150},
+		// 1 台 100 kWh，4 小时高峰每小时最多削去 25 kW
+		{"energy", 16, 1, 275},
+	}
+	for _, tt := range tests {
+		battery := demandBattery(demandTestCabinet, tt.cabinets)
+		if got := lowestTarget(battery, demandTestMonth(tt.peakSlots), 10000); math.Abs(got-tt.want) > 2*demandTolerance {
+			t.Errorf("%s: lowestTarget = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGroupDemandMonthsDays(t *testing.T) {
+	start := time.Date(2023, 1, 30, 0, 0, 0, 0, time.Local)
+	end := time.Date(2023, 2, 2, 12, 0, 0, 0, time.Local)
+	var points []loadPoint
+	for ts := start; !ts.After(end); ts = ts.Add(slotMinutes * time.Minute) {
+		points = append(points, loadPoint{time: ts, load: 100})
+	}
+	months := groupDemandMonths(points)
+	if len(months) != 2 || months[0].days != 2 || months[1].days != 2 {
+		t.Fatalf("groupDemandMonths returned %d months, want January and February with 2 days each", len(months))
+	}
+}
@@ -0,0 +1,155 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DemandManagementLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDemandManagementLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DemandManagementLogic {
+	return &DemandManagementLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DemandManagementLogic) DemandManagement(req *types.DemandRequest) (*types.DemandResponse, error) {
+	resolved, err := l.resolveRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := l.evaluate(resolved)
+	if err != nil {
+		return nil, err
+	}
+	resp.ScenarioId = recordScenario(l.ctx, l.svcCtx, demandScenario(resolved, resp))
+	return resp, nil
+}
+
+// 填入实际使用的储能柜参数，保存的方案重新测算时不受产品修改的影响
+func (l *DemandManagementLogic) resolveRequest(req *types.DemandRequest) (*types.DemandRequest, error) {
+	product, err := findProduct(l.ctx, l.svcCtx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := productCabinetSpec(product, req.Cabinet)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *req
+	resolved.Cabinet = spec.params()
+	return &resolved, nil
+}
+
+// 测算需量管理的台数或需量，不保存方案
+func (l *DemandManagementLogic) evaluate(req *types.DemandRequest) (*types.DemandResponse, error) {
+	l.Logger.Infof("Evaluating demand management: company=%s, product=%d, target=%v, cabinets=%d", req.Company, req.ProductId, req.TargetDemand, req.CabinetCount)
+
+	if (req.TargetDemand > 0) == (req.CabinetCount > 0) {
+		return nil, fmt.Errorf("exactly one of targetDemand and cabinetCount must be positive")
+	}
+	if req.TargetDemand < 0 || req.CabinetCount < 0 {
+		return nil, fmt.Errorf("targetDemand and cabinetCount must not be negative")
+	}
+	if req.CabinetCount > maxDemandCabinets {
+		return nil, fmt.Errorf("cabinetCount must not exceed %d", maxDemandCabinets)
+	}
+
+	demandPrice := req.DemandPrice
+	if demandPrice == 0 && req.TariffId != 0 {
+		tariff, _, err := findTariff(l.ctx, l.svcCtx, req.TariffId)
+		if err != nil {
+			return nil, err
+		}
+		startTime, endTime, err := parseTimeRange(req.StartTime, req.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkTariffEffective(tariff, startTime, endTime); err != nil {
+			return nil, err
+		}
+		demandPrice = tariff.DemandPrice
+	}
+	if demandPrice <= 0 {
+		return nil, fmt.Errorf("a positive demandPrice is required, either in the request or in the tariff")
+	}
+
+	product, err := findProduct(l.ctx, l.svcCtx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := productCabinetSpec(product, req.Cabinet)
+	if err != nil {
+		return nil, err
+	}
+	data, err := queryPowerSeries(l.ctx, l.svcCtx, req.StartTime, req.EndTime, req.Company)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no power data for company %s between %s and %s", req.Company, req.StartTime, req.EndTime)
+	}
+	months := groupDemandMonths(loadPoints(data, req.MeterMultiplier))
+	gridLimit := req.TransformerCapacity * req.PowerFactor
+
+	resp := &types.DemandResponse{
+		DemandPrice:  demandPrice,
+		CabinetCount: req.CabinetCount,
+		TargetDemand: req.TargetDemand,
+	}
+	// 指定目标需量时先测算最少台数，再按该台数削峰
+	if req.TargetDemand > 0 {
+		count, ok := cabinetsForTarget(spec, months, req.TargetDemand, gridLimit)
+		if !ok {
+			return nil, fmt.Errorf("target demand %v kW cannot be reached with up to %d cabinets", req.TargetDemand, maxDemandCabinets)
+		}
+		resp.CabinetCount = count
+	}
+
+	// 与电费测算相同按需量计收基本电费，计费需量不低于变压器容量的 40%
+	rule := basicChargeRule{mode: basicChargeDemand, demandPrice: demandPrice, transformerCapacity: req.TransformerCapacity}
+	battery := demandBattery(spec, resp.CabinetCount)
+	days := 0
+	for _, month := range months {
+		// 指定台数时每月取能守住的最低目标
+		target := math.Min(req.TargetDemand, month.peak)
+		if req.TargetDemand == 0 {
+			target = lowestTarget(battery, month, gridLimit)
+		}
+		shaved, shavedTime := shavedPeak(battery, month, target, gridLimit)
+
+		result := types.DemandMonth{
+			Month:        month.month,
+			Days:         month.days,
+			PeakDemand:   month.peak,
+			PeakTime:     month.peakTime.Format(dateTimeLayout),
+			TargetDemand: target,
+			ShavedDemand: shaved,
+			ShavedTime:   shavedTime.Format(dateTimeLayout),
+			Reduction:    month.peak - shaved,
+			BaseCharge:   rule.charge(month.peak),
+			ShavedCharge: rule.charge(shaved),
+		}
+		result.Savings = result.BaseCharge - result.ShavedCharge
+		resp.Months = append(resp.Months, result)
+		resp.TotalSavings += result.Savings
+		days += month.days
+	}
+	// 与套利收益相同按覆盖的天数折算为全年，不足一个月的月份不按整月计
+	resp.AnnualSavings = resp.TotalSavings * 365 / float64(days)
+
+	l.Logger.Infof("Demand management over %d months with %d cabinets: savings %.2f", len(resp.Months), resp.CabinetCount, resp.TotalSavings)
+	return resp, nil
+}
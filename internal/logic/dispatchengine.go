@@ -0,0 +1,139 @@
+package logic
+
+import (
+	"math"
+	"time"
+)
+
+// 储能系统模型，能量和功率均为整个系统的额定值
+type batteryModel struct {
+	energy              float64 // 额定能量 (kWh)
+	power               float64 // 最大充放电功率 (kW)
+	minSoc              float64 // 最低荷电状态 (0-1)
+	maxSoc              float64 // 最高荷电状态 (0-1)
+	initialSoc          float64 // 初始荷电状态 (0-1)
+	roundTripEfficiency float64 // 往返效率 (0-1)，充电和放电各承担一半损耗
+	auxiliaryPower      float64 // 辅助用电功率 (kW)，充电或放电时由电网供给
+}
+
+// 单向效率，往返效率平均分摊到充电和放电
+func (b batteryModel) oneWayEfficiency() float64 {
+	return math.Sqrt(b.roundTripEfficiency)
+}
+
+// 一个 15 分钟时刻的负荷 (kW)
+type loadPoint struct {
+	time time.Time
+	load float64
+}
+
+// 调度策略：根据时刻、负荷和当前荷电状态给出期望功率 (kW)，正数为充电，负数为放电
+// 引擎会再按电池功率、荷电状态、变压器容量和负荷对期望功率进行裁剪
+type dispatchStrategy interface {
+	desiredPower(point loadPoint, soc float64, battery batteryModel) float64
+}
+
+// 按时刻表调度：充电时段满功率充电，放电时段满功率放电，其余时刻待机
+type scheduleStrategy struct {
+	chargeWindows    []clockWindow
+	dischargeWindows []clockWindow
+}
+
+func (s scheduleStrategy) desiredPower(point loadPoint, soc float64, battery batteryModel) float64 {
+	slot := slotOfDay(point.time)
+	for _, w := range s.chargeWindows {
+		if w.activeAt(slot) {
+			return battery.power
+		}
+	}
+	for _, w := range s.dischargeWindows {
+		if w.activeAt(slot) {
+			return -battery.power
+		}
+	}
+	return 0
+}
+
+// 削峰调度：负荷超过目标需量时放电削峰，低于目标时以不超过目标的功率充电，为下一次削峰做准备
+type peakShavingStrategy struct {
+	target float64 // 目标需量 (kW)
+}
+
+func (s peakShavingStrategy) desiredPower(point loadPoint, soc float64, battery batteryModel) float64 {
+	// 储能动作时辅助用电也计入电网负荷
+	return s.target - point.load - battery.auxiliaryPower
+}
+
+// 一个时刻的调度结果
+type dispatchSlot struct {
+	time      time.Time
+	load      float64 // 场站负荷 (kW)
+	charge    float64 // 充电功率 (kW，电网侧)
+	discharge float64 // 放电功率 (kW，交流侧)
+	auxiliary float64 // 辅助用电功率 (kW)，仅在充电或放电时计入
+	soc       float64 // 时刻结束时的荷电状态
+	netLoad   float64 // 储能动作后的电网负荷 (kW)
+}
+
+// 逐时刻模拟储能调度
+// gridLimit 为充电时电网侧允许的最大负荷 (kW)，为 0 时不限制；放电功率不超过当时负荷，不向电网反送电
+func simulateDispatch(battery batteryModel, points []loadPoint, strategy dispatchStrategy, gridLimit float64) []dispatchSlot {
+	slotHours := 1 / float64(slotsPerHour)
+	efficiency := battery.oneWayEfficiency()
+	soc := math.Min(math.Max(battery.initialSoc, battery.minSoc), battery.maxSoc)
+
+	slots := make([]dispatchSlot, 0, len(points))
+	for _, point := range points {
+		result := dispatchSlot{time: point.time, load: point.load}
+		desired := strategy.desiredPower(point, soc, battery)
+		if desired > 0 && battery.energy > 0 {
+			// 充电受功率、剩余可充电量和变压器容量限制
+			charge := math.Min(desired, battery.power)
+			charge = math.Min(charge, (battery.maxSoc-soc)*battery.energy/efficiency/slotHours)
+			if gridLimit > 0 {
+				charge = math.Min(charge, gridLimit-point.load-battery.auxiliaryPower)
+			}
+			charge = math.Max(charge, 0)
+			soc += charge * slotHours * efficiency / battery.energy
+			result.charge = charge
+		} else if desired < 0 && battery.energy > 0 {
+			// 放电受功率、剩余可放电量和当时负荷限制
+			discharge := math.Min(-desired, battery.power)
+			discharge = math.Min(discharge, (soc-battery.minSoc)*battery.energy*efficiency/slotHours)
+			discharge = math.Min(discharge, point.load)
+			discharge = math.Max(discharge, 0)
+			soc -= discharge * slotHours / efficiency / battery.energy
+			result.discharge = discharge
+		}
+		if result.charge > 0 || result.discharge > 0 {
+			result.auxiliary = battery.auxiliaryPower
+		}
+		result.soc = soc
+		result.netLoad = point.load + result.charge - result.discharge + result.auxiliary
+		slots = append(slots, result)
+	}
+	return slots
+}
+
+// 每日充放电量
+type dailyThroughput struct {
+	date      string
+	charge    float64 // 充电量 (kWh，电网侧)
+	discharge float64 // 放电量 (kWh)
+}
+
+// 按日期汇总调度结果的充放电量，按日期升序排列
+func summarizeDispatchDays(slots []dispatchSlot) []dailyThroughput {
+	slotHours := 1 / float64(slotsPerHour)
+	var days []dailyThroughput
+	for _, slot := range slots {
+		date := slot.time.Format(dateLayout)
+		if len(days) == 0 || days[len(days)-1].date != date {
+			days = append(days, dailyThroughput{date: date})
+		}
+		day := &days[len(days)-1]
+		day.charge += slot.charge * slotHours
+		day.discharge += slot.discharge * slotHours
+	}
+	return days
+}
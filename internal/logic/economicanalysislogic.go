@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type EconomicAnalysisLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewEconomicAnalysisLogic(ctx context.Context, svcCtx *svc.ServiceContext) *EconomicAnalysisLogic {
+	return &EconomicAnalysisLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *EconomicAnalysisLogic) EconomicAnalysis(req *types.EconomicsRequest) (*types.EconomicsResponse, error) {
+	l.Logger.Infof("Analysing economics: company=%s, tariff=%d, product=%d, cabinets=%d", req.Company, req.TariffId, req.ProductId, req.CabinetCount)
+
+	inv := investment{
+		capitalCost:     req.CapitalCost,
+		annualOmCost:    req.AnnualOmCost,
+		lifeYears:       req.LifeYears,
+		discountRate:    req.DiscountRate,
+		degradationRate: req.DegradationRate,
+	}
+	if err := inv.validate(); err != nil {
+		return nil, err
+	}
+
+	// 指定产品时按产品参数和台数组装储能系统，投资默认取产品单价 × 台数
+	var battery batteryModel
+	if req.ProductId != 0 {
+		if req.CabinetCount <= 0 {
+			return nil, fmt.Errorf("cabinetCount must be positive when productId is given")
+		}
+		product, err := findProduct(l.ctx, l.svcCtx, req.ProductId)
+		if err != nil {
+			return nil, err
+		}
+		spec, err := productCabinetSpec(product, req.Cabinet)
+		if err != nil {
+			return nil, err
+		}
+		battery = spec.battery(req.CabinetCount)
+		inv.capitalCost = defaultIfZero(inv.capitalCost, product.UnitCost*float64(req.CabinetCount))
+	} else {
+		var err error
+		battery, err = batteryFromConfig(req.Battery)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	startTime, endTime, err := parseTimeRange(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := loadTariffSchedules(l.ctx, l.svcCtx, req.TariffId, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	data, err := queryPowerSeries(l.ctx, l.svcCtx, req.StartTime, req.EndTime, req.Company)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no power data for company %s between %s and %s", req.Company, req.StartTime, req.EndTime)
+	}
+
+	// 按电价调度并计价，收益按模拟天数折算为全年
+	slots := simulateDispatch(battery, loadPoints(data, req.MeterMultiplier), tariffStrategy{schedules: schedules}, req.TransformerCapacity*req.PowerFactor)
+	value := priceDispatch(slots, schedules)
+	annual := value.annualized()
+
+	result := evaluateInvestment(inv, annual.revenue())
+
+	resp := &types.EconomicsResponse{
+		SimulatedDays:         value.days,
+		BatteryEnergy:         battery.energy,
+		BatteryPower:          battery.power,
+		AnnualChargeEnergy:    annual.chargeEnergy,
+		AnnualDischargeEnergy: annual.dischargeEnergy,
+		AnnualChargeCost:      annual.chargeCost,
+		AnnualDischargeValue:  annual.dischargeValue,
+		AnnualRevenue:         annual.revenue(),
+		AnnualOmCost:          req.AnnualOmCost,
+		CapitalCost:           inv.capitalCost,
+		SimplePayback:         result.simplePayback,
+		Npv:                   result.npv,
+		Irr:                   result.irr,
+		IrrAvailable:          result.irrAvailable,
+		CashFlows:             make([]types.CashFlowYear, 0, len(result.years)),
+	}
+	for _, year := range result.years {
+		resp.CashFlows = append(resp.CashFlows, types.CashFlowYear{
+			Year:               year.year,
+			Revenue:            year.revenue,
+			OmCost:             year.omCost,
+			NetCashFlow:        year.netCashFlow,
+			CumulativeCashFlow: year.cumulative,
+			PresentValue:       year.presentValue,
+		})
+	}
+
+	l.Logger.Infof("Economics: annual revenue %.2f, NPV %.2f, payback %.2f years", resp.AnnualRevenue, resp.Npv, resp.SimplePayback)
+	return resp, nil
+}
@@ -0,0 +1,167 @@
+package logic
+
+import (
+	"fmt"
+	"math"
+)
+
+// 按分时电价调度：低谷满功率充电，高峰和尖峰满功率放电，平段待机，各月份使用当月的电价表
+type tariffStrategy struct {
+	schedules [12]tariffSchedule
+}
+
+func (s tariffStrategy) desiredPower(point loadPoint, soc float64, battery batteryModel) float64 {
+	schedule := s.schedules[point.time.Month()-1]
+	switch tariffAction(schedule.kinds[slotOfDay(point.time)]) {
+	case periodCharge:
+		return battery.power
+	case periodDischarge:
+		return -battery.power
+	default:
+		return 0
+	}
+}
+
+// 调度结果按电价计算的充电电费和放电节省的电费 (元)
+type arbitrageValue struct {
+	chargeEnergy    float64 // 充电量 (kWh)，含辅助用电
+	dischargeEnergy float64 // 放电量 (kWh)
+	chargeCost      float64 // 充电电费
+	dischargeValue  float64 // 放电节省的电费
+	days            int     // 调度覆盖的天数
+}
+
+// 峰谷套利收益 = 放电节省的电费 - 充电电费
+func (v arbitrageValue) revenue() float64 {
+	return v.dischargeValue - v.chargeCost
+}
+
+// 折算为全年的值
+func (v arbitrageValue) annualized() arbitrageValue {
+	if v.days == 0 {
+		return arbitrageValue{}
+	}
+	scale := 365 / float64(v.days)
+	return arbitrageValue{
+		chargeEnergy:    v.chargeEnergy * scale,
+		dischargeEnergy: v.dischargeEnergy * scale,
+		chargeCost:      v.chargeCost * scale,
+		dischargeValue:  v.dischargeValue * scale,
+		days:            365,
+	}
+}
+
+// 按每个时刻的电价对调度结果计价
+func priceDispatch(slots []dispatchSlot, schedules [12]tariffSchedule) arbitrageValue {
+	slotHours := 1 / float64(slotsPerHour)
+	var value arbitrageValue
+	for _, slot := range slots {
+		price := schedules[slot.time.Month()-1].prices[slotOfDay(slot.time)]
+		value.chargeEnergy += (slot.charge + slot.auxiliary) * slotHours
+		value.dischargeEnergy += slot.discharge * slotHours
+		value.chargeCost += (slot.charge + slot.auxiliary) * slotHours * price
+		value.dischargeValue += slot.discharge * slotHours * price
+	}
+	value.days = len(summarizeDispatchDays(slots))
+	return value
+}
+
+// 投资测算参数
+type investment struct {
+	capitalCost     float64 // 初始投资 (元)
+	annualOmCost    float64 // 年运维费用 (元)
+	lifeYears       int     // 运行年限
+	discountRate    float64 // 折现率
+	degradationRate float64 // 年衰减率，第 n 年收益为首年的 (1-衰减率)^(n-1)
+}
+
+func (inv investment) validate() error {
+	if inv.lifeYears <= 0 {
+		return fmt.Errorf("lifeYears must be positive")
+	}
+	if inv.discountRate <= -1 {
+		return fmt.Errorf("discountRate must be greater than -1, got %v", inv.discountRate)
+	}
+	if inv.degradationRate < 0 || inv.degradationRate >= 1 {
+		return fmt.Errorf("degradationRate must be in [0, 1), got %v", inv.degradationRate)
+	}
+	if inv.capitalCost < 0 || inv.annualOmCost < 0 {
+		return fmt.Errorf("capital and O&M costs must not be negative")
+	}
+	return nil
+}
+
+// 逐年现金流
+type cashFlowYear struct {
+	year         int
+	revenue      float64
+	omCost       float64
+	netCashFlow  float64
+	cumulative   float64 // 含初始投资的累计现金流
+	presentValue float64 // 净现金流的现值
+}
+
+// 投资测算结果
+type investmentResult struct {
+	years         []cashFlowYear
+	simplePayback float64 // 静态投资回收期 (年)，首年净现金流不为正时为 0
+	npv           float64
+	irr           float64
+	irrAvailable  bool
+}
+
+// 按首年收益计算全生命周期的现金流、静态回收期、净现值和内部收益率
+func evaluateInvestment(inv investment, annualRevenue float64) investmentResult {
+	flows := make([]float64, inv.lifeYears+1)
+	flows[0] = -inv.capitalCost
+
+	result := investmentResult{npv: -inv.capitalCost}
+	cumulative := -inv.capitalCost
+	for year := 1; year <= inv.lifeYears; year++ {
+		revenue := annualRevenue * math.Pow(1-inv.degradationRate, float64(year-1))
+		net := revenue - inv.annualOmCost
+		cumulative += net
+		presentValue := net / math.Pow(1+inv.discountRate, float64(year))
+		flows[year] = net
+		result.npv += presentValue
+		result.years = append(result.years, cashFlowYear{
+			year:         year,
+			revenue:      revenue,
+			omCost:       inv.annualOmCost,
+			netCashFlow:  net,
+			cumulative:   cumulative,
+			presentValue: presentValue,
+		})
+	}
+
+	if firstYear := annualRevenue - inv.annualOmCost; firstYear > 0 {
+		result.simplePayback = inv.capitalCost / firstYear
+	}
+	result.irr, result.irrAvailable = internalRateOfReturn(flows)
+	return result
+}
+
+// 二分法求内部收益率，净现值在 (-99%, 1000%] 内不变号时返回 false
+func internalRateOfReturn(flows []float64) (float64, bool) {
+	npv := func(rate float64) float64 {
+		var total float64
+		for year, flow := range flows {
+			total += flow / math.Pow(1+rate, float64(year))
+		}
+		return total
+	}
+
+	low, high := -0.99, 10.0
+	if npv(low)*npv(high) > 0 {
+		return 0, false
+	}
+	for i := 0; i < 200; i++ {
+		mid := (low + high) / 2
+		if npv(low)*npv(mid) <= 0 {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return (low + high) / 2, true
+}
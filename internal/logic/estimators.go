@@ -0,0 +1,200 @@
+package logic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 负荷统计量估计器：由一组功率值求出代表功率
+type estimator func(powers []float64) float64
+
+// 已注册的估计器，name 为方法名，build 根据冒号后的参数构造估计器，无参数的估计器收到空字符串
+type estimatorFactory struct {
+	name        string
+	description string
+	parameter   string // 参数说明，为空表示不接受参数
+	example     string
+	build       func(param string) (estimator, error)
+}
+
+var (
+	estimatorRegistry = make(map[string]estimatorFactory)
+	// 兼容旧版本的方法名，映射为等价的表达式
+	estimatorAliases = make(map[string]string)
+)
+
+// 注册估计器，新的计算方法只需在 init 中调用，无需修改测算逻辑
+func registerEstimator(factory estimatorFactory) {
+	if _, ok := estimatorRegistry[factory.name]; ok {
+		panic(fmt.Sprintf("estimator %s registered twice", factory.name))
+	}
+	estimatorRegistry[factory.name] = factory
+}
+
+// 注册旧方法名
+func registerEstimatorAlias(alias, expression string) {
+	estimatorAliases[alias] = expression
+}
+
+// 无参数估计器
+func fixedEstimator(f estimator) func(string) (estimator, error) {
+	return func(param string) (estimator, error) {
+		if param != "" {
+			return nil, fmt.Errorf("does not take a parameter")
+		}
+		return f, nil
+	}
+}
+
+// 带 [min, max] 范围内数值参数的估计器
+func paramEstimator(min, max float64, f func(powers []float64, param float64) float64) func(string) (estimator, error) {
+	return func(param string) (estimator, error) {
+		value, err := strconv.ParseFloat(param, 64)
+		if err != nil || value < min || value > max {
+			return nil, fmt.Errorf("parameter must be a number in [%v, %v], got %q", min, max, param)
+		}
+		return func(powers []float64) float64 {
+			return f(powers, value)
+		}, nil
+	}
+}
+
+func init() {
+	registerEstimator(estimatorFactory{name: "average", description: "平均数", example: "average", build: fixedEstimator(calculateAverage)})
+	registerEstimator(estimatorFactory{name: "mean", description: "平均数，与 average 相同", example: "mean", build: fixedEstimator(calculateAverage)})
+	registerEstimator(estimatorFactory{name: "median", description: "中位数", example: "median", build: fixedEstimator(calculateMedian)})
+	registerEstimator(estimatorFactory{name: "mode", description: "众数", example: "mode", build: fixedEstimator(calculateMode)})
+	registerEstimator(estimatorFactory{name: "quartile", description: "四分位数 Q1、Q2、Q3 的平均值", example: "quartile", build: fixedEstimator(calculateQuartile)})
+	registerEstimator(estimatorFactory{name: "stddev", description: "总体标准差，通常与 mean 组合使用", example: "mean+1.5*stddev", build: fixedEstimator(calculateStdDev)})
+	registerEstimator(estimatorFactory{name: "max", description: "最大值", example: "max", build: fixedEstimator(calculateMax)})
+	registerEstimator(estimatorFactory{name: "min", description: "最小值", example: "min", build: fixedEstimator(calculateMin)})
+	registerEstimator(estimatorFactory{name: "percentile", description: "百分位数（线性插值）", parameter: "百分位 (0-100)", example: "percentile:85",
+		build: paramEstimator(0, 100, calculatePercentile)})
+	registerEstimator(estimatorFactory{name: "trimmed_mean", description: "截尾平均数，去掉最高和最低各一定比例的数据后求平均", parameter: "每端去掉的百分比 (0-50)", example: "trimmed_mean:10",
+		build: paramEstimator(0, 50, calculateTrimmedMean)})
+
+	registerEstimatorAlias("percentile90", "percentile:90")
+	registerEstimatorAlias("stddev_mean", "mean+stddev")
+}
+
+// 解析计算方法，支持单个估计器 (median、percentile:85)、旧方法名 (percentile90)
+// 以及估计器的加权线性组合 (mean+1.5*stddev、0.5*median+0.5*percentile:90)
+func parseEstimator(method string) (estimator, error) {
+	expression := strings.ReplaceAll(method, " ", "")
+	if alias, ok := estimatorAliases[expression]; ok {
+		expression = alias
+	}
+	terms, err := splitEstimatorTerms(expression)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported calculation method %s: %v", method, err)
+	}
+
+	weights := make([]float64, len(terms))
+	estimators := make([]estimator, len(terms))
+	for i, term := range terms {
+		weights[i], estimators[i], err = parseEstimatorTerm(term)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported calculation method %s: %v", method, err)
+		}
+	}
+	if len(terms) == 1 && weights[0] == 1 {
+		return estimators[0], nil
+	}
+	return func(powers []float64) float64 {
+		var value float64
+		for i, e := range estimators {
+			value += weights[i] * e(powers)
+		}
+		return value
+	}, nil
+}
+
+// 按加号和减号拆分组合表达式，减号并入后一项的系数
+func splitEstimatorTerms(expression string) ([]string, error) {
+	if expression == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+	var terms []string
+	start := 0
+	for i := 1; i < len(expression); i++ {
+		if (expression[i] == '+' || expression[i] == '-') && !isExponentSign(expression, i) {
+			terms = append(terms, expression[start:i])
+			start = i
+		}
+	}
+	terms = append(terms, expression[start:])
+	for i, term := range terms {
+		terms[i] = strings.TrimPrefix(term, "+")
+		if terms[i] == "" || terms[i] == "-" {
+			return nil, fmt.Errorf("empty term in expression")
+		}
+	}
+	return terms, nil
+}
+
+// 判断 expression[i] 处的正负号是否属于科学计数法的指数，例如 1.5e-3；
+// 只有 e/E 紧跟在数字或小数点之后才算指数，average+stddev 等以 e 结尾的名称后仍按分隔符处理
+func isExponentSign(expression string, i int) bool {
+	if i < 2 || (expression[i-1] != 'e' && expression[i-1] != 'E') {
+		return false
+	}
+	c := expression[i-2]
+	return (c >= '0' && c <= '9') || c == '.'
+}
+
+// 解析组合中的一项：[系数*]名称[:参数]
+func parseEstimatorTerm(term string) (float64, estimator, error) {
+	weight := 1.0
+	if strings.HasPrefix(term, "-") {
+		weight = -1
+		term = term[1:]
+	}
+	if coefficient, rest, ok := strings.Cut(term, "*"); ok {
+		value, err := strconv.ParseFloat(coefficient, 64)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid coefficient %q", coefficient)
+		}
+		weight *= value
+		term = rest
+	}
+
+	name, param, _ := strings.Cut(term, ":")
+	// 旧版本的 percentileNN 写法
+	if _, ok := estimatorRegistry[name]; !ok && strings.HasPrefix(name, "percentile") && param == "" {
+		name, param = "percentile", strings.TrimPrefix(name, "percentile")
+	}
+	factory, ok := estimatorRegistry[name]
+	if !ok {
+		return 0, nil, fmt.Errorf("unknown estimator %q", name)
+	}
+	e, err := factory.build(param)
+	if err != nil {
+		return 0, nil, fmt.Errorf("estimator %s %v", name, err)
+	}
+	return weight, e, nil
+}
+
+// 按名称排序的已注册估计器
+func registeredEstimators() []estimatorFactory {
+	factories := make([]estimatorFactory, 0, len(estimatorRegistry))
+	for _, factory := range estimatorRegistry {
+		factories = append(factories, factory)
+	}
+	sort.Slice(factories, func(i, j int) bool {
+		return factories[i].name < factories[j].name
+	})
+	return factories
+}
+
+// 计算截尾平均数，每端去掉 percent% 的数据，全部去掉时退化为中位数
+func calculateTrimmedMean(powers []float64, percent float64) float64 {
+	sorted := sortedCopy(powers)
+	trim := int(math.Floor(float64(len(sorted)) * percent / 100))
+	if 2*trim >= len(sorted) {
+		return calculateMedian(sorted)
+	}
+	return calculateAverage(sorted[trim : len(sorted)-trim])
+}
@@ -0,0 +1,76 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseEstimatorCombinations(t *testing.T) {
+	powers := []float64{120, 80, 95, 110, 95, 130, 70, 100}
+	maxValue := calculateMax(powers)
+
+	for _, factory := range registeredEstimators() {
+		term := factory.name
+		if factory.parameter != "" {
+			term = factory.example
+		}
+		single, err := parseEstimator(term)
+		if err != nil {
+			t.Fatalf("parseEstimator(%q) failed: %v", term, err)
+		}
+		value := single(powers)
+
+		tests := []struct {
+			expression string
+			want       float64
+		}{
+			{term + "+max", value + maxValue},
+			{term + "-max", value - maxValue},
+			{"max+" + term, maxValue + value},
+			{"max-" + term, maxValue - value},
+			{term + "+0.5*max", value + 0.5*maxValue},
+			{term + "-0.5*max", value - 0.5*maxValue},
+			{"2*" + term + "-1e-1*max", 2*value - 0.1*maxValue},
+		}
+		for _, tt := range tests {
+			e, err := parseEstimator(tt.expression)
+			if err != nil {
+				t.Errorf("parseEstimator(%q) failed: %v", tt.expression, err)
+				continue
+			}
+			if got := e(powers); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("parseEstimator(%q) = %v, want %v", tt.expression, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSplitEstimatorTermsScientificNotation(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       []string
+	}{
+		{"average+stddev", []string{"average", "stddev"}},
+		{"mode+stddev", []string{"mode", "stddev"}},
+		{"quartile-0.5*stddev", []string{"quartile", "-0.5*stddev"}},
+		{"mean+1.5e-1*stddev", []string{"mean", "1.5e-1*stddev"}},
+		{"mean-2.E+0*stddev", []string{"mean", "-2.E+0*stddev"}},
+	}
+	for _, tt := range tests {
+		got, err := splitEstimatorTerms(tt.expression)
+		if err != nil {
+			t.Errorf("splitEstimatorTerms(%q) failed: %v", tt.expression, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("splitEstimatorTerms(%q) = %q, want %q", tt.expression, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("splitEstimatorTerms(%q) = %q, want %q", tt.expression, got, tt.want)
+				break
+			}
+		}
+	}
+}
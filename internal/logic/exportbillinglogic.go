@@ -0,0 +1,114 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ExportBillingLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	writer http.ResponseWriter
+}
+
+func NewExportBillingLogic(ctx context.Context, svcCtx *svc.ServiceContext, writer http.ResponseWriter) *ExportBillingLogic {
+	return &ExportBillingLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		writer: writer,
+	}
+}
+
+func (l *ExportBillingLogic) ExportBilling(req *types.BillingExportRequest) error {
+	l.Logger.Infof("Exporting bills: company=%s, startTime=%s, endTime=%s, tariff=%d, format=%s",
+		req.Billing.Company, req.Billing.StartTime, req.Billing.EndTime, req.Billing.TariffId, req.Format)
+
+	bills, err := simulateBilling(l.ctx, l.svcCtx, &req.Billing)
+	if err != nil {
+		return err
+	}
+	sheets := []exportSheet{{name: "电费对比", rows: billingExportRows(bills)}}
+
+	// 填写容量测算参数时，与电费一起导出容量测算结果
+	if req.Capacity.Company != "" {
+		capacity, err := NewCalculateCapacityLogic(l.ctx, l.svcCtx).calculate(&req.Capacity)
+		if err != nil {
+			return fmt.Errorf("failed to calculate capacity: %v", err)
+		}
+		sheets = append(sheets, exportSheet{name: "容量测算", rows: capacityExportRows(capacity)})
+	}
+
+	fileName := fmt.Sprintf("%s_bills_%s", req.Billing.Company, time.Now().Format("20060102150405"))
+	l.Logger.Infof("Writing %d sheets to %s.%s", len(sheets), fileName, req.Format)
+	return writeExportFile(l.writer, req.Format, fileName, sheets)
+}
+
+// 电费对比表：每月一行基线电费，配置储能时再加一行储能后的电费
+func billingExportRows(bills *types.BillingResponse) [][]interface{} {
+	header := []interface{}{"月份", "方案"}
+	for _, kind := range tariffKinds {
+		label := tariffKindLabels[kind]
+		header = append(header, label+"电量(kWh)", label+"电费(元)")
+	}
+	header = append(header, "总电量(kWh)", "电度电费(元)", "最大需量(kW)", "基本电费(元)", "电费合计(元)", "节省(元)")
+	rows := [][]interface{}{header}
+
+	billRow := func(bill types.MonthlyBill, scenario string, savings interface{}) []interface{} {
+		items := make(map[string]types.BillItem, len(bill.Items))
+		for _, item := range bill.Items {
+			items[item.Kind] = item
+		}
+		row := []interface{}{bill.Month, scenario}
+		for _, kind := range tariffKinds {
+			row = append(row, items[kind].Energy, items[kind].Charge)
+		}
+		return append(row, bill.Energy, bill.EnergyCharge, bill.MaxDemand, bill.BasicCharge, bill.Total, savings)
+	}
+	for _, month := range bills.Months {
+		rows = append(rows, billRow(month.Baseline, "基线", ""))
+		if bills.HasStorage {
+			rows = append(rows, billRow(month.WithStorage, "储能", month.Savings))
+		}
+	}
+	// 合计行留空中间各列，使合计金额和节省落在电费合计和节省两列
+	totalRow := func(scenario string, total, savings interface{}) []interface{} {
+		row := make([]interface{}, len(header))
+		for i := range row {
+			row[i] = ""
+		}
+		row[0], row[1] = "合计", scenario
+		row[len(row)-2], row[len(row)-1] = total, savings
+		return row
+	}
+	rows = append(rows, totalRow("基线", bills.BaselineTotal, ""))
+	if bills.HasStorage {
+		rows = append(rows, totalRow("储能", bills.StorageTotal, bills.TotalSavings))
+	}
+	return rows
+}
+
+// 容量测算表：先列出最小台数和决定台数的约束，再列出各时段的测算结果
+func capacityExportRows(capacity *types.CapacityConfigResponse) [][]interface{} {
+	rows := [][]interface{}{
+		{"最小台数", capacity.MinCabinetCount},
+		{"决定台数的时段", capacity.LimitingPeriod},
+		{"决定台数的约束", capacity.BindingConstraint},
+	}
+	if capacity.ConstrainingDay != "" {
+		rows = append(rows, []interface{}{"决定台数的日期", capacity.ConstrainingDay})
+	}
+	rows = append(rows, []interface{}{}, []interface{}{"类型", "开始时间", "结束时间", "统计功率(kW)", "时长(h)", "充放电量(kWh)", "单台可用电量(kWh)", "台数", "约束"})
+	for _, p := range capacity.Periods {
+		rows = append(rows, []interface{}{p.Kind, p.Start, p.End, p.Power, p.Hours, p.Amount, p.CabinetEnergy, p.Cabinets, p.Constraint})
+	}
+	return rows
+}
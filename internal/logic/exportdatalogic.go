@@ -0,0 +1,215 @@
+package logic
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ExportDataLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	writer http.ResponseWriter
+}
+
+func NewExportDataLogic(ctx context.Context, svcCtx *svc.ServiceContext, writer http.ResponseWriter) *ExportDataLogic {
+	return &ExportDataLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		writer: writer,
+	}
+}
+
+// 导出文件中的一个工作表，CSV 格式只输出第一个工作表
+type exportSheet struct {
+	name string
+	rows [][]interface{}
+}
+
+func (l *ExportDataLogic) ExportData(req *types.ExportRequest) error {
+	l.Logger.Infof("Exporting data: company=%s, startTime=%s, endTime=%s, format=%s, layout=%s, interval=%s",
+		req.Company, req.StartTime, req.EndTime, req.Format, req.Layout, req.Interval)
+
+	if req.Layout == "wide" && req.Interval != "15m" {
+		return fmt.Errorf("wide layout only supports 15m interval, got %s", req.Interval)
+	}
+	if _, err := calculateStatistic(req.Method, nil); err != nil {
+		return err
+	}
+
+	data, err := queryPowerSeries(l.ctx, l.svcCtx, req.StartTime, req.EndTime, req.Company)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]interface{}
+	if req.Layout == "wide" {
+		rows = wideExportRows(data, req.Company)
+	} else {
+		rows, err = longExportRows(data, req.Interval, req.Method)
+		if err != nil {
+			return err
+		}
+	}
+
+	fileName := fmt.Sprintf("%s_%s_%s", req.Company, req.Layout, time.Now().Format("20060102150405"))
+	l.Logger.Infof("Writing %d rows to %s.%s", len(rows), fileName, req.Format)
+	return writeExportFile(l.writer, req.Format, fileName, []exportSheet{{name: "功率数据", rows: rows}})
+}
+
+// 长表格式：每行一个时刻，表头与上传解析识别的列名一致，导出的文件可直接重新上传
+func longExportRows(data []model.PowerData, interval, method string) ([][]interface{}, error) {
+	rows := [][]interface{}{{"数据时间", "总有功功率"}}
+	if interval == "15m" {
+		for _, d := range data {
+			rows = append(rows, []interface{}{d.DataTime.Format(dateTimeLayout), d.Power})
+		}
+		return rows, nil
+	}
+
+	// 按小时或按天聚合，每个区间使用指定的统计方法
+	buckets := make(map[time.Time][]float64)
+	for _, d := range data {
+		var bucket time.Time
+		if interval == "1h" {
+			bucket = d.DataTime.Truncate(time.Hour)
+		} else {
+			bucket = startOfDay(d.DataTime)
+		}
+		buckets[bucket] = append(buckets[bucket], d.Power)
+	}
+	keys := make([]time.Time, 0, len(buckets))
+	for key := range buckets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Before(keys[j]) })
+	for _, key := range keys {
+		power, err := calculateStatistic(method, buckets[key])
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, []interface{}{key.Format(dateTimeLayout), power})
+	}
+	return rows, nil
+}
+
+// 宽表格式：与上传解析的"数据日期"格式一致，前两行为表头，之后每行一天，第 4 列起为 96 个时刻的有功功率
+func wideExportRows(data []model.PowerData, company string) [][]interface{} {
+	header := []interface{}{"数据日期", "数据类型", "单位"}
+	for _, label := range slotLabels() {
+		header = append(header, label)
+	}
+	rows := [][]interface{}{header, {"公司名称", company}}
+
+	days := make(map[string][]interface{})
+	var dates []string
+	for _, d := range data {
+		date := d.DataTime.Format(dateLayout)
+		values, ok := days[date]
+		if !ok {
+			// 缺失的时刻留空，重新上传时该日期会因数据不足 96 条被剔除
+			values = make([]interface{}, slotsPerDay)
+			for i := range values {
+				values[i] = ""
+			}
+			days[date] = values
+			dates = append(dates, date)
+		}
+		values[slotOfDay(d.DataTime)] = d.Power
+	}
+	for _, date := range dates {
+		row := append([]interface{}{date, "有功功率", "kW"}, days[date]...)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// 将工作表以 CSV 或 XLSX 格式写入响应，CSV 有多个工作表时依次写入，每个工作表前空一行并写入表名
+func writeExportFile(w http.ResponseWriter, format, fileName string, sheets []exportSheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("nothing to export")
+	}
+
+	switch format {
+	case "csv":
+		// 不写入 UTF-8 BOM，否则重新上传时表头无法被识别
+		setAttachmentHeaders(w, "text/csv; charset=utf-8", fileName+".csv")
+		writer := csv.NewWriter(w)
+		for i, sheet := range sheets {
+			// 只有一个工作表时不写表名，保证导出的数据可以重新上传
+			if len(sheets) > 1 {
+				if i > 0 {
+					if err := writer.Write([]string{}); err != nil {
+						return err
+					}
+				}
+				if err := writer.Write([]string{sheet.name}); err != nil {
+					return err
+				}
+			}
+			for _, row := range sheet.rows {
+				record := make([]string, len(row))
+				for j, cell := range row {
+					record[j] = formatExportCell(cell)
+				}
+				if err := writer.Write(record); err != nil {
+					return err
+				}
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case "xlsx":
+		f := excelize.NewFile()
+		defer f.Close()
+		for i, sheet := range sheets {
+			if i == 0 {
+				if err := f.SetSheetName(f.GetSheetName(0), sheet.name); err != nil {
+					return err
+				}
+			} else if _, err := f.NewSheet(sheet.name); err != nil {
+				return err
+			}
+			for j, row := range sheet.rows {
+				cell, _ := excelize.CoordinatesToCellName(1, j+1)
+				if err := f.SetSheetRow(sheet.name, cell, &row); err != nil {
+					return err
+				}
+			}
+		}
+		setAttachmentHeaders(w, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", fileName+".xlsx")
+		return f.Write(w)
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func setAttachmentHeaders(w http.ResponseWriter, contentType, fileName string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(fileName)))
+}
+
+func formatExportCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"power/internal/types"
+)
+
+func TestWriteExportFileCSV(t *testing.T) {
+	sheets := []exportSheet{
+		{name: "电费对比", rows: [][]interface{}{{"月份", "电费合计(元)"}, {"2023-05", 1234.5}}},
+		{name: "容量测算", rows: [][]interface{}{{"最小台数", 3}}},
+	}
+	tests := []struct {
+		name   string
+		sheets []exportSheet
+		want   string
+	}{
+		// 单个工作表不写表名，可以重新上传
+		{"single sheet", sheets[:1], "月份,电费合计(元)\n2023-05,1234.5\n"},
+		{"multiple sheets", sheets, "电费对比\n月份,电费合计(元)\n2023-05,1234.5\n\n容量测算\n最小台数,3\n"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		if err := writeExportFile(w, "csv", "bills", tt.sheets); err != nil {
+			t.Fatalf("%s: writeExportFile failed: %v", tt.name, err)
+		}
+		if got := w.Body.String(); got != tt.want {
+			t.Errorf("%s: csv = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBillingExportTotals(t *testing.T) {
+	rows := billingExportRows(&types.BillingResponse{HasStorage: true, BaselineTotal: 1000, StorageTotal: 800, TotalSavings: 200})
+	header := rows[0]
+	totalColumn, savingsColumn := len(header)-2, len(header)-1
+	if header[totalColumn] != "电费合计(元)" || header[savingsColumn] != "节省(元)" {
+		t.Fatalf("header ends with %v, %v", header[totalColumn], header[savingsColumn])
+	}
+	for _, tt := range []struct {
+		row            []interface{}
+		total, savings interface{}
+	}{
+		{rows[len(rows)-2], 1000.0, ""},
+		{rows[len(rows)-1], 800.0, 200.0},
+	} {
+		if len(tt.row) != len(header) || tt.row[totalColumn] != tt.total || tt.row[savingsColumn] != tt.savings {
+			t.Errorf("totals row = %v, want %v and %v under the total and savings columns", tt.row, tt.total, tt.savings)
+		}
+	}
+}
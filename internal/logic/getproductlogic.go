@@ -0,0 +1,32 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetProductLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetProductLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetProductLogic {
+	return &GetProductLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetProductLogic) GetProduct(req *types.ProductIdRequest) (*types.Product, error) {
+	product, err := findProduct(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return productFromModel(product), nil
+}
@@ -0,0 +1,28 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetScenarioLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetScenarioLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetScenarioLogic {
+	return &GetScenarioLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetScenarioLogic) GetScenario(req *types.ScenarioIdRequest) (*types.Scenario, error) {
+	return loadScenario(l.ctx, l.svcCtx, req.Id)
+}
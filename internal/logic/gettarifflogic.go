@@ -0,0 +1,32 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetTariffLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetTariffLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetTariffLogic {
+	return &GetTariffLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetTariffLogic) GetTariff(req *types.TariffIdRequest) (*types.Tariff, error) {
+	tariff, periods, err := findTariff(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return tariffFromModel(tariff, periods), nil
+}
@@ -0,0 +1,94 @@
+package logic
+
+import (
+	"context"
+	"math"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type HeatmapLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewHeatmapLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HeatmapLogic {
+	return &HeatmapLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *HeatmapLogic) Heatmap(req *types.HeatmapRequest) (*types.HeatmapResponse, error) {
+	l.Logger.Infof("Building heatmap: company=%s, startTime=%s, endTime=%s", req.Company, req.StartTime, req.EndTime)
+
+	startTime, endTime, err := parseTimeRange(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	data, err := queryPowerSeries(l.ctx, l.svcCtx, req.StartTime, req.EndTime, req.Company)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按日期和时刻归类，present 标记该时刻是否有数据
+	type dayValues struct {
+		values  []float64
+		present []bool
+	}
+	days := make(map[string]*dayValues)
+	minPower, maxPower := math.Inf(1), math.Inf(-1)
+	for _, d := range data {
+		date := d.DataTime.Format(dateLayout)
+		day, ok := days[date]
+		if !ok {
+			day = &dayValues{
+				values:  make([]float64, slotsPerDay),
+				present: make([]bool, slotsPerDay),
+			}
+			days[date] = day
+		}
+		slot := slotOfDay(d.DataTime)
+		day.values[slot] = d.Power
+		day.present[slot] = true
+		minPower = math.Min(minPower, d.Power)
+		maxPower = math.Max(maxPower, d.Power)
+	}
+	if len(data) == 0 {
+		minPower, maxPower = 0, 0
+	}
+
+	// 逐日列出查询范围内的所有日期，无数据的日期整行标记为缺失
+	var rows []types.HeatmapRow
+	for date := startOfDay(startTime); !date.After(endTime); date = date.AddDate(0, 0, 1) {
+		key := date.Format(dateLayout)
+		row := types.HeatmapRow{
+			Date:   key,
+			Values: make([]float64, slotsPerDay),
+		}
+		day, ok := days[key]
+		if ok {
+			row.Values = day.values
+		}
+		for slot := 0; slot < slotsPerDay; slot++ {
+			if !ok || !day.present[slot] {
+				row.Missing = append(row.Missing, slot)
+			}
+		}
+		row.Complete = len(row.Missing) == 0
+		rows = append(rows, row)
+	}
+
+	l.Logger.Infof("Built heatmap with %d days from %d data points", len(rows), len(data))
+	return &types.HeatmapResponse{
+		Slots:    slotLabels(),
+		Rows:     rows,
+		MinPower: minPower,
+		MaxPower: maxPower,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListCompaniesLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListCompaniesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListCompaniesLogic {
+	return &ListCompaniesLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListCompaniesLogic) ListCompanies() (*types.CompanyListResponse, error) {
+	summaries, err := l.svcCtx.Model.QueryCompanySummaries(l.ctx)
+	if err != nil {
+		l.Logger.Error("Database query failed: ", err)
+		return nil, err
+	}
+
+	uploads, err := l.svcCtx.UploadLogModel.FindLatestPerCompany(l.ctx)
+	if err != nil {
+		l.Logger.Error("Failed to query upload logs: ", err)
+		return nil, err
+	}
+	latestUploads := make(map[string]model.UploadLog, len(uploads))
+	for _, upload := range uploads {
+		latestUploads[upload.Company] = upload
+	}
+
+	// 一次查询所有公司的每日数据量，再按公司分组
+	allCounts, err := l.svcCtx.Model.QueryAllDailyCounts(l.ctx)
+	if err != nil {
+		l.Logger.Error("Database query failed: ", err)
+		return nil, err
+	}
+	dailyCounts := make(map[string][]model.DailyCount, len(summaries))
+	for _, count := range allCounts {
+		dailyCounts[count.Company] = append(dailyCounts[count.Company], model.DailyCount{
+			DataDate:  count.DataDate,
+			SlotCount: count.SlotCount,
+		})
+	}
+
+	companies := make([]types.CompanyInfo, 0, len(summaries))
+	for _, summary := range summaries {
+		info := types.CompanyInfo{
+			Company:   summary.Company,
+			FirstTime: summary.FirstTime.Format(dateTimeLayout),
+			LastTime:  summary.LastTime.Format(dateTimeLayout),
+			RowCount:  summary.RowCount,
+		}
+		for _, day := range buildCoverageDays(dailyCounts[summary.Company]) {
+			if day.Complete {
+				info.CompleteDays++
+			} else {
+				info.MissingDays++
+			}
+		}
+		if upload, ok := latestUploads[summary.Company]; ok {
+			info.LastUploadTime = upload.CreateTime.Format(dateTimeLayout)
+			info.LastUploadFile = upload.FileName
+		}
+		companies = append(companies, info)
+	}
+
+	l.Logger.Infof("Listed %d companies", len(companies))
+	return &types.CompanyListResponse{
+		Companies: companies,
+	}, nil
+}
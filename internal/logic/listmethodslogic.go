@@ -0,0 +1,47 @@
+package logic
+
+import (
+	"context"
+	"sort"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListMethodsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListMethodsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListMethodsLogic {
+	return &ListMethodsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListMethodsLogic) ListMethods() (*types.MethodListResponse, error) {
+	resp := &types.MethodListResponse{}
+	for _, factory := range registeredEstimators() {
+		resp.Methods = append(resp.Methods, types.MethodInfo{
+			Name:        factory.name,
+			Description: factory.description,
+			Parameter:   factory.parameter,
+			Example:     factory.example,
+		})
+	}
+	for alias, expression := range estimatorAliases {
+		resp.Aliases = append(resp.Aliases, types.MethodAlias{
+			Name:       alias,
+			Expression: expression,
+		})
+	}
+	sort.Slice(resp.Aliases, func(i, j int) bool {
+		return resp.Aliases[i].Name < resp.Aliases[j].Name
+	})
+	return resp, nil
+}
@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListProductsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListProductsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListProductsLogic {
+	return &ListProductsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListProductsLogic) ListProducts() (*types.ProductListResponse, error) {
+	products, err := l.svcCtx.ProductModel.FindAll(l.ctx)
+	if err != nil {
+		l.Logger.Error("Failed to query products: ", err)
+		return nil, err
+	}
+
+	resp := &types.ProductListResponse{
+		Products: make([]types.Product, 0, len(products)),
+	}
+	for i := range products {
+		resp.Products = append(resp.Products, *productFromModel(&products[i]))
+	}
+	return resp, nil
+}
@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListScenariosLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListScenariosLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListScenariosLogic {
+	return &ListScenariosLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListScenariosLogic) ListScenarios(req *types.ScenarioListRequest) (*types.ScenarioListResponse, error) {
+	if req.Limit <= 0 || req.Limit > maxScenarioListLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxScenarioListLimit)
+	}
+	if req.Offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative")
+	}
+	scenarios, err := l.svcCtx.ScenarioModel.FindAll(l.ctx, model.ScenarioFilter{
+		Company: req.Company,
+		Kind:    req.Kind,
+		Author:  req.Author,
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	})
+	if err != nil {
+		l.Logger.Error("Failed to query scenarios: ", err)
+		return nil, err
+	}
+
+	resp := &types.ScenarioListResponse{
+		Scenarios: make([]types.ScenarioSummary, 0, len(scenarios)),
+	}
+	for i := range scenarios {
+		resp.Scenarios = append(resp.Scenarios, scenarioSummaryFromModel(&scenarios[i]))
+	}
+	return resp, nil
+}
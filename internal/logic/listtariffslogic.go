@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListTariffsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListTariffsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListTariffsLogic {
+	return &ListTariffsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListTariffsLogic) ListTariffs(req *types.TariffListRequest) (*types.TariffListResponse, error) {
+	tariffs, err := l.svcCtx.TariffModel.FindAll(l.ctx, req.Province)
+	if err != nil {
+		l.Logger.Error("Failed to query tariffs: ", err)
+		return nil, err
+	}
+
+	resp := &types.TariffListResponse{
+		Tariffs: make([]types.Tariff, 0, len(tariffs)),
+	}
+	for i := range tariffs {
+		periods, err := l.svcCtx.TariffPeriodModel.FindByTariffId(l.ctx, tariffs[i].Id)
+		if err != nil {
+			l.Logger.Error("Failed to query tariff periods: ", err)
+			return nil, err
+		}
+		resp.Tariffs = append(resp.Tariffs, *tariffFromModel(&tariffs[i], periods))
+	}
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type LoadDurationLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewLoadDurationLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LoadDurationLogic {
+	return &LoadDurationLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *LoadDurationLogic) LoadDuration(req *types.LoadDurationRequest) (*types.LoadDurationResponse, error) {
+	l.Logger.Infof("Analysing load duration: company=%s, startTime=%s, endTime=%s", req.Company, req.StartTime, req.EndTime)
+
+	if req.Points < 2 {
+		return nil, fmt.Errorf("points must be at least 2, got %d", req.Points)
+	}
+
+	data, err := queryPowerSeries(l.ctx, l.svcCtx, req.StartTime, req.EndTime, req.Company)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no power data for company %s between %s and %s", req.Company, req.StartTime, req.EndTime)
+	}
+
+	// 乘以电表倍率得到实际负荷，并按降序排列得到负荷持续曲线
+	powers := make([]float64, len(data))
+	for i, d := range data {
+		powers[i] = d.Power * req.MeterMultiplier
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(powers)))
+
+	count := len(powers)
+	slotHours := 1 / float64(slotsPerHour)
+	peakPower := powers[0]
+	minPower := powers[count-1]
+	averagePower := calculateAverage(powers)
+
+	resp := &types.LoadDurationResponse{
+		Points:       downsampleDurationCurve(powers, req.Points),
+		PeakPower:    peakPower,
+		AveragePower: averagePower,
+		MinPower:     minPower,
+		TotalHours:   float64(count) * slotHours,
+		TotalEnergy:  averagePower * float64(count) * slotHours,
+	}
+	if peakPower > 0 {
+		resp.LoadFactor = averagePower / peakPower
+	}
+
+	// 统计高于各阈值的小时数
+	for _, threshold := range req.Thresholds {
+		above := sort.Search(count, func(i int) bool { return powers[i] <= threshold })
+		resp.ThresholdHours = append(resp.ThresholdHours, types.ThresholdHours{
+			Threshold: threshold,
+			Hours:     float64(above) * slotHours,
+			Percent:   float64(above) / float64(count) * 100,
+		})
+	}
+
+	// 变压器负载率
+	if req.TransformerCapacity > 0 && req.PowerFactor > 0 {
+		ratedPower := req.TransformerCapacity * req.PowerFactor
+		resp.PeakLoadingRate = peakPower / ratedPower
+		resp.AverageLoadingRate = averagePower / ratedPower
+	}
+
+	l.Logger.Infof("Load duration: peak=%.2f kW, average=%.2f kW, load factor=%.3f", peakPower, averagePower, resp.LoadFactor)
+	return resp, nil
+}
+
+// 将降序排列的负荷等间隔抽取为指定点数，首尾点分别为最大和最小负荷
+func downsampleDurationCurve(powers []float64, points int) []types.DurationPoint {
+	count := len(powers)
+	if points > count {
+		points = count
+	}
+	slotHours := 1 / float64(slotsPerHour)
+	result := make([]types.DurationPoint, 0, points)
+	for i := 0; i < points; i++ {
+		index := 0
+		if points > 1 {
+			index = int(math.Round(float64(i) * float64(count-1) / float64(points-1)))
+		}
+		result = append(result, types.DurationPoint{
+			Percent: float64(index+1) / float64(count) * 100,
+			Hours:   float64(index+1) * slotHours,
+			Power:   powers[index],
+		})
+	}
+	return result
+}
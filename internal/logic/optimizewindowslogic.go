@@ -0,0 +1,129 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type OptimizeWindowsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewOptimizeWindowsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *OptimizeWindowsLogic {
+	return &OptimizeWindowsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *OptimizeWindowsLogic) OptimizeWindows(req *types.CapacityConfigRequest) (*types.WindowOptimizationResponse, error) {
+	resolved, err := l.resolveRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := l.optimize(resolved)
+	if err != nil {
+		return nil, err
+	}
+	resp.ScenarioId = recordScenario(l.ctx, l.svcCtx, capacityScenario(scenarioOptimize, resolved, resp))
+	return resp, nil
+}
+
+// 填入实际使用的储能柜参数，充放电时段由优化得出，仍按电价生成
+func (l *OptimizeWindowsLogic) resolveRequest(req *types.CapacityConfigRequest) (*types.CapacityConfigRequest, error) {
+	spec, err := cabinetSpecFor(l.ctx, l.svcCtx, req)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *req
+	spec.fill(&resolved)
+	return &resolved, nil
+}
+
+// 优化充放电时段，不保存方案
+func (l *OptimizeWindowsLogic) optimize(req *types.CapacityConfigRequest) (*types.WindowOptimizationResponse, error) {
+	l.Logger.Infof("Optimising windows: company=%s, tariff=%d, startDate=%s, endDate=%s, method=%s", req.Company, req.TariffId, req.StartDate, req.EndDate, req.CalculationMethod)
+
+	if req.TariffId == 0 {
+		return nil, fmt.Errorf("tariffId is required to optimise windows")
+	}
+	if req.StartDate == "" || req.EndDate == "" {
+		return nil, fmt.Errorf("window optimisation requires startDate and endDate")
+	}
+	if _, err := calculateStatistic(req.CalculationMethod, nil); err != nil {
+		return nil, err
+	}
+	spec, err := cabinetSpecFor(l.ctx, l.svcCtx, req)
+	if err != nil {
+		return nil, err
+	}
+	month, err := tariffMonth(req)
+	if err != nil {
+		return nil, err
+	}
+	startDate, endDate, err := parseDateRange(req)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := loadTariffSchedules(l.ctx, l.svcCtx, req.TariffId, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := schedules[month-1].blocks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive periods from tariff %d: %v", req.TariffId, err)
+	}
+	if err := checkUniformBlocks(schedules, month, blocks, startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	data, err := queryDailySeries(l.ctx, l.svcCtx, req.Company, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query power data: %v", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no power data for company %s between %s and %s", req.Company, req.StartDate, req.EndDate)
+	}
+	optimizer := newWindowOptimizer(req, spec, schedules, data, startDate, endDate)
+
+	// 按电价直接生成的时段，即搜索的起点
+	defaultPeriods, err := schedules[month-1].capacityPeriods()
+	if err != nil {
+		return nil, err
+	}
+	tariffDefault, err := optimizer.evaluate(defaultPeriods)
+	if err != nil {
+		return nil, err
+	}
+	optimal, err := optimizer.optimize(blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &types.WindowOptimizationResponse{
+		Optimal:       optimal.schedule(),
+		TariffDefault: tariffDefault.schedule(),
+		Improvement:   optimal.dailyValue() - tariffDefault.dailyValue(),
+	}
+	if len(req.Periods) > 0 {
+		userSupplied, err := optimizer.evaluate(req.Periods)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate supplied periods: %v", err)
+		}
+		resp.UserSupplied = userSupplied.schedule()
+		resp.Improvement = optimal.dailyValue() - userSupplied.dailyValue()
+	}
+	resp.EvaluatedCandidates = len(optimizer.evaluated)
+
+	l.Logger.Infof("Evaluated %d window sets: optimal %d cabinets, %.2f per day, %.2f better than baseline",
+		resp.EvaluatedCandidates, optimal.cabinetCount, optimal.dailyValue(), resp.Improvement)
+	return resp, nil
+}
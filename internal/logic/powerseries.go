@@ -0,0 +1,225 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+)
+
+const (
+	// 数据库及接口中统一使用的时间格式
+	dateTimeLayout = "2006-01-02 15:04:05"
+	dateLayout     = "2006-01-02"
+	monthLayout    = "2006-01"
+	// 每天 96 个 15 分钟采样点，与上传解析时的校验保持一致
+	slotsPerDay  = 96
+	slotMinutes  = 15
+	slotsPerHour = 60 / slotMinutes
+)
+
+// 加载上海时区，确保与上传、查询时的时区保持一致
+func loadLocation() (*time.Location, error) {
+	return time.LoadLocation("Asia/Shanghai")
+}
+
+// 解析查询时间范围，开始和结束时间均按上海时区解析
+func parseTimeRange(startTimeStr, endTimeStr string) (time.Time, time.Time, error) {
+	location, err := loadLocation()
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to load location: %v", err)
+	}
+	startTime, err := time.ParseInLocation(dateTimeLayout, startTimeStr, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start time %s: %v", startTimeStr, err)
+	}
+	endTime, err := time.ParseInLocation(dateTimeLayout, endTimeStr, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end time %s: %v", endTimeStr, err)
+	}
+	if endTime.Before(startTime) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end time %s is before start time %s", endTimeStr, startTimeStr)
+	}
+	return startTime, endTime, nil
+}
+
+// 结束时间早于开始时间且相差不足一天时，视为跨零点的时段（例如 22:00 至次日 08:00），结束时间顺延一天
+// 只用于容量测算的充放电时段，查询、导出等接口的时间范围不顺延，结束时间早于开始时间时报错
+func wrapEndTime(startTime, endTime time.Time) time.Time {
+	if endTime.Before(startTime) && endTime.AddDate(0, 0, 1).After(startTime) {
+		return endTime.AddDate(0, 0, 1)
+	}
+	return endTime
+}
+
+// 按字符串顺延跨零点时段的结束时间，无法解析时原样返回，由后续查询报错
+func wrapPeriodEnd(startTimeStr, endTimeStr string) string {
+	location, err := loadLocation()
+	if err != nil {
+		return endTimeStr
+	}
+	startTime, err := time.ParseInLocation(dateTimeLayout, startTimeStr, location)
+	if err != nil {
+		return endTimeStr
+	}
+	endTime, err := time.ParseInLocation(dateTimeLayout, endTimeStr, location)
+	if err != nil {
+		return endTimeStr
+	}
+	return wrapEndTime(startTime, endTime).Format(dateTimeLayout)
+}
+
+// 按时间范围和公司名称查询原始功率序列
+func queryPowerSeries(ctx context.Context, svcCtx *svc.ServiceContext, startTimeStr, endTimeStr, company string) ([]model.PowerData, error) {
+	startTime, endTime, err := parseTimeRange(startTimeStr, endTimeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := svcCtx.Model.QueryData(ctx, startTime, endTime, company)
+	if err != nil {
+		return nil, err
+	}
+	// 数据库驱动返回的时间统一转换到上海时区，避免按日分组时跨天
+	for i := range data {
+		data[i].DataTime = data[i].DataTime.In(startTime.Location())
+	}
+	return data, nil
+}
+
+// 时间点所在日期的零点
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// 计算时间点在当天的 15 分钟时段序号（0-95）
+func slotOfDay(t time.Time) int {
+	return (t.Hour()*60 + t.Minute()) / slotMinutes
+}
+
+// 时段序号对应的时刻标签，例如 0 -> "00:00"，95 -> "23:45"
+func slotLabel(slot int) string {
+	minutes := slot * slotMinutes
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// 全天 96 个时段的时刻标签
+func slotLabels() []string {
+	labels := make([]string, slotsPerDay)
+	for i := range labels {
+		labels[i] = slotLabel(i)
+	}
+	return labels
+}
+
+// 一天 96 个时刻的功率，present 标记该时刻是否有数据
+type daySeries struct {
+	date    time.Time
+	values  [slotsPerDay]float64
+	present [slotsPerDay]bool
+}
+
+// 取出当天 [startSlot, endSlot] 时刻（含两端）中有数据的时刻和功率
+func (d *daySeries) window(startSlot, endSlot int) []types.PowerData {
+	var data []types.PowerData
+	for slot := startSlot; slot <= endSlot; slot++ {
+		if d.present[slot] {
+			data = append(data, types.PowerData{
+				Time:  d.date.Add(time.Duration(slot*slotMinutes) * time.Minute).Format(dateTimeLayout),
+				Power: d.values[slot],
+			})
+		}
+	}
+	return data
+}
+
+// 将功率序列按日期分组，键为 YYYY-MM-DD
+func groupByDay(data []model.PowerData) map[string]*daySeries {
+	days := make(map[string]*daySeries)
+	for _, d := range data {
+		key := d.DataTime.Format(dateLayout)
+		day, ok := days[key]
+		if !ok {
+			day = &daySeries{date: startOfDay(d.DataTime)}
+			days[key] = day
+		}
+		slot := slotOfDay(d.DataTime)
+		day.values[slot] = d.Power
+		day.present[slot] = true
+	}
+	return days
+}
+
+// 解析当天时刻 (HH:MM 或 HH:MM:SS)，返回所在的 15 分钟时段序号
+func parseClockSlot(clock string) (int, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, clock); err == nil {
+			return slotOfDay(t), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time of day %s, expected HH:MM", clock)
+}
+
+// 解析时刻表示的时段
+func parseClockWindow(start, end string) (clockWindow, error) {
+	startSlot, err := parseClockSlot(start)
+	if err != nil {
+		return clockWindow{}, err
+	}
+	endSlot, err := parseClockSlot(end)
+	if err != nil {
+		return clockWindow{}, err
+	}
+	return clockWindow{startSlot: startSlot, endSlot: endSlot}, nil
+}
+
+// 当天时刻表示的测算时段，起止时刻均包含在内
+// 结束时刻早于开始时刻时跨零点，从前一天的开始时刻持续到当天的结束时刻，电量计入当天
+type clockWindow struct {
+	startSlot int
+	endSlot   int
+}
+
+// 是否跨零点
+func (w clockWindow) wraps() bool {
+	return w.endSlot < w.startSlot
+}
+
+// 调度时时刻序号是否处于时段内，按左闭右开处理，结束时刻归属下一个时段，避免相邻时段重叠
+func (w clockWindow) activeAt(slot int) bool {
+	if w.wraps() {
+		return slot >= w.startSlot || slot < w.endSlot
+	}
+	return slot >= w.startSlot && slot < w.endSlot
+}
+
+// 时段包含的数据点数
+func (w clockWindow) slots() int {
+	if w.wraps() {
+		return slotsPerDay - w.startSlot + w.endSlot + 1
+	}
+	return w.endSlot - w.startSlot + 1
+}
+
+// 取出运行日 date 在该时段内有数据的时刻和功率，跨零点的时段包含前一天的数据
+func (w clockWindow) data(days map[string]*daySeries, date time.Time) []types.PowerData {
+	day, ok := days[date.Format(dateLayout)]
+	if !w.wraps() {
+		if !ok {
+			return nil
+		}
+		return day.window(w.startSlot, w.endSlot)
+	}
+
+	var data []types.PowerData
+	if previous, ok := days[date.AddDate(0, 0, -1).Format(dateLayout)]; ok {
+		data = append(data, previous.window(w.startSlot, slotsPerDay-1)...)
+	}
+	if ok {
+		data = append(data, day.window(0, w.endSlot)...)
+	}
+	return data
+}
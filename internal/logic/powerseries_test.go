@@ -0,0 +1,39 @@
+package logic
+
+import "testing"
+
+func TestParseTimeRangeRejectsReversedRange(t *testing.T) {
+	tests := []struct {
+		start, end string
+		wantErr    bool
+	}{
+		{"2023-05-01 00:00:00", "2023-05-31 23:45:00", false},
+		{"2023-05-01 08:00:00", "2023-05-01 08:00:00", false},
+		// 相差不足一天的倒序范围同样报错，不按跨零点顺延
+		{"2023-05-01 22:00:00", "2023-05-01 08:00:00", true},
+		{"2023-05-31 00:00:00", "2023-05-01 00:00:00", true},
+	}
+	for _, tt := range tests {
+		_, _, err := parseTimeRange(tt.start, tt.end)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseTimeRange(%q, %q) error = %v, wantErr %v", tt.start, tt.end, err, tt.wantErr)
+		}
+	}
+}
+
+func TestWrapPeriodEnd(t *testing.T) {
+	tests := []struct {
+		start, end, want string
+	}{
+		{"2023-05-01 22:00:00", "2023-05-01 08:00:00", "2023-05-02 08:00:00"},
+		{"2023-05-01 08:00:00", "2023-05-01 11:00:00", "2023-05-01 11:00:00"},
+		// 相差超过一天的倒序时段不顺延，由查询报错
+		{"2023-05-03 22:00:00", "2023-05-01 08:00:00", "2023-05-01 08:00:00"},
+		{"2023-05-01 22:00:00", "invalid", "invalid"},
+	}
+	for _, tt := range tests {
+		if got := wrapPeriodEnd(tt.start, tt.end); got != tt.want {
+			t.Errorf("wrapPeriodEnd(%q, %q) = %q, want %q", tt.start, tt.end, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package logic
+
+import (
+	"math"
+	"sort"
+)
+
+// 根据计算方法对一组功率值求统计量，计算方法的写法见 parseEstimator，可用的估计器见 estimators.go
+func calculateStatistic(method string, powers []float64) (float64, error) {
+	e, err := parseEstimator(method)
+	if err != nil {
+		return 0, err
+	}
+	return e(powers), nil
+}
+
+// 计算平均数
+func calculateAverage(powers []float64) float64 {
+	if len(powers) == 0 {
+		return 0
+	}
+	var total float64
+	for _, power := range powers {
+		total += power
+	}
+	return total / float64(len(powers))
+}
+
+// 计算中位数
+func calculateMedian(powers []float64) float64 {
+	sorted := sortedCopy(powers)
+	length := len(sorted)
+	if length == 0 {
+		return 0
+	}
+	if length%2 == 0 {
+		return (sorted[length/2-1] + sorted[length/2]) / 2
+	}
+	return sorted[length/2]
+}
+
+// 计算众数，出现次数相同时取最先达到该次数的值
+func calculateMode(powers []float64) float64 {
+	frequencyMap := make(map[float64]int)
+	var mode float64
+	maxFrequency := 0
+	for _, power := range powers {
+		frequencyMap[power]++
+		if frequencyMap[power] > maxFrequency {
+			mode = power
+			maxFrequency = frequencyMap[power]
+		}
+	}
+	return mode
+}
+
+// 计算百分位数（线性插值）
+func calculatePercentile(powers []float64, percentile float64) float64 {
+	sorted := sortedCopy(powers)
+	length := len(sorted)
+	if length == 0 {
+		return 0
+	}
+	index := percentile / 100 * float64(length-1)
+	lower := int(math.Floor(index))
+	upper := int(math.Ceil(index))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(index-float64(lower))
+}
+
+// 计算四分位数（Q1、Q2、Q3的平均值）
+func calculateQuartile(powers []float64) float64 {
+	q1 := calculatePercentile(powers, 25)
+	q2 := calculatePercentile(powers, 50) // 中位数
+	q3 := calculatePercentile(powers, 75)
+	return (q1 + q2 + q3) / 3
+}
+
+// 计算总体标准差
+func calculateStdDev(powers []float64) float64 {
+	length := len(powers)
+	if length == 0 {
+		return 0
+	}
+	mean := calculateAverage(powers)
+	var variance float64
+	for _, power := range powers {
+		variance += math.Pow(power-mean, 2)
+	}
+	return math.Sqrt(variance / float64(length))
+}
+
+// 计算最大值
+func calculateMax(powers []float64) float64 {
+	if len(powers) == 0 {
+		return 0
+	}
+	max := powers[0]
+	for _, power := range powers[1:] {
+		max = math.Max(max, power)
+	}
+	return max
+}
+
+// 计算最小值
+func calculateMin(powers []float64) float64 {
+	if len(powers) == 0 {
+		return 0
+	}
+	min := powers[0]
+	for _, power := range powers[1:] {
+		min = math.Min(min, power)
+	}
+	return min
+}
+
+// 复制并升序排序，避免修改调用方的数据
+func sortedCopy(powers []float64) []float64 {
+	sorted := make([]float64, len(powers))
+	copy(sorted, powers)
+	sort.Float64s(sorted)
+	return sorted
+}
@@ -2,6 +2,7 @@ package logic
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"power/internal/svc"
@@ -47,6 +48,11 @@ func (l *QueryDataLogic) QueryData(req *types.QueryRequest) (*types.QueryRespons
 		return nil, err
 	}
 
+	if endTime.Before(startTime) {
+		l.Logger.Errorf("End time is before start time: %s < %s", req.EndTime, req.StartTime)
+		return nil, fmt.Errorf("end time %s is before start time %s", req.EndTime, req.StartTime)
+	}
+
 	// 记录解析后的时间信息
 	l.Logger.Infof("Parsed times: startTime=%v, endTime=%v", startTime, endTime)
 
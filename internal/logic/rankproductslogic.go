@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RankProductsLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRankProductsLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RankProductsLogic {
+	return &RankProductsLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RankProductsLogic) RankProducts(req *types.ProductRankRequest) (*types.ProductRankResponse, error) {
+	products, err := l.svcCtx.ProductModel.FindAll(l.ctx)
+	if err != nil {
+		l.Logger.Error("Failed to query products: ", err)
+		return nil, err
+	}
+	if len(req.ProductIds) > 0 {
+		wanted := make(map[int64]bool, len(req.ProductIds))
+		for _, id := range req.ProductIds {
+			wanted[id] = true
+		}
+		var selected []model.StorageProduct
+		for _, product := range products {
+			if wanted[product.Id] {
+				selected = append(selected, product)
+			}
+		}
+		products = selected
+	}
+	if len(products) == 0 {
+		return nil, fmt.Errorf("no products to rank")
+	}
+
+	capacityLogic := NewCalculateCapacityLogic(l.ctx, l.svcCtx)
+	ranks := make([]types.ProductRank, 0, len(products))
+	for i := range products {
+		product := &products[i]
+
+		// 储能柜容量、效率、放电深度、辅助用电和 PCS 功率全部取产品参数
+		capacityReq := req.Capacity
+		capacityReq.ProductId = product.Id
+		capacityReq.ChargeCapacity = 0
+		capacityReq.DischargeCapacity = 0
+		capacityReq.RoundTripEfficiency = 0
+		capacityReq.PcsEfficiency = 0
+		capacityReq.DepthOfDischarge = 0
+		capacityReq.AuxiliaryPower = 0
+		capacityReq.PcsPower = 0
+		capacity, err := capacityLogic.calculate(&capacityReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate capacity for product %s: %v", product.Name, err)
+		}
+
+		count := float64(capacity.MinCabinetCount)
+		rank := types.ProductRank{
+			Product:         *productFromModel(product),
+			MinCabinetCount: capacity.MinCabinetCount,
+			InstalledEnergy: count * product.EnergyCapacity,
+			InstalledPower:  count * product.PcsPower,
+			TotalCost:       count * product.UnitCost,
+			TotalFootprint:  count * product.Footprint,
+		}
+		// 每个放电时段的放电量不超过负荷需要的放电量
+		for _, period := range capacity.Periods {
+			if period.Kind == periodDischarge {
+				rank.DailyDischarge += math.Min(period.Amount, count*period.CabinetEnergy)
+			}
+		}
+		if rank.DailyDischarge > 0 {
+			rank.CostPerKwh = rank.TotalCost / rank.DailyDischarge
+		}
+		ranks = append(ranks, rank)
+	}
+
+	// 按每 kWh 日放电量的投资升序排列，无法放电的产品排在最后
+	sort.SliceStable(ranks, func(i, j int) bool {
+		if (ranks[i].DailyDischarge > 0) != (ranks[j].DailyDischarge > 0) {
+			return ranks[i].DailyDischarge > 0
+		}
+		return ranks[i].CostPerKwh < ranks[j].CostPerKwh
+	})
+
+	l.Logger.Infof("Ranked %d products for company %s", len(ranks), req.Capacity.Company)
+	return &types.ProductRankResponse{
+		Ranks: ranks,
+	}, nil
+}
@@ -0,0 +1,338 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 测算方案的类型，对应保存方案的测算接口
+const (
+	scenarioCapacity = "capacity" // POST /capacity/
+	scenarioOptimize = "optimize" // POST /capacity/optimize
+	scenarioDemand   = "demand"   // POST /capacity/demand
+)
+
+const (
+	maxScenarioListLimit = 500 // 方案列表一次返回的最大条数
+	maxDiffItems         = 24  // 对比方案时，超过该条数的列表只比较条数，不逐项比较
+)
+
+// 一次测算需要保存的内容
+type scenarioInput struct {
+	kind      string
+	name      string
+	author    string
+	company   string
+	method    string
+	dataStart string
+	dataEnd   string
+	request   interface{} // 不含方案名称和创建人的测算参数
+	result    interface{}
+}
+
+// 容量测算的方案内容，按日测算时数据范围取 startDate/endDate，否则取各时段的最早开始和最晚结束时间
+func capacityScenario(kind string, req *types.CapacityConfigRequest, result interface{}) scenarioInput {
+	request := *req
+	request.ScenarioName, request.Author = "", ""
+	input := scenarioInput{
+		kind:      kind,
+		name:      req.ScenarioName,
+		author:    req.Author,
+		company:   req.Company,
+		method:    req.CalculationMethod,
+		dataStart: req.StartDate,
+		dataEnd:   req.EndDate,
+		request:   &request,
+		result:    result,
+	}
+	if req.StartDate == "" && req.EndDate == "" {
+		for _, p := range req.Periods {
+			if input.dataStart == "" || p.Start < input.dataStart {
+				input.dataStart = p.Start
+			}
+			if p.End > input.dataEnd {
+				input.dataEnd = p.End
+			}
+		}
+	}
+	return input
+}
+
+// 需量管理的方案内容
+func demandScenario(req *types.DemandRequest, result interface{}) scenarioInput {
+	request := *req
+	request.ScenarioName, request.Author = "", ""
+	return scenarioInput{
+		kind:      scenarioDemand,
+		name:      req.ScenarioName,
+		author:    req.Author,
+		company:   req.Company,
+		dataStart: req.StartTime,
+		dataEnd:   req.EndTime,
+		request:   &request,
+		result:    result,
+	}
+}
+
+// 公司当前的数据版本，即最近一次上传记录的 id，无上传记录时为 0
+func dataVersion(ctx context.Context, svcCtx *svc.ServiceContext, company string) (int64, error) {
+	upload, err := svcCtx.UploadLogModel.FindLatestByCompany(ctx, company)
+	if err == model.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return upload.Id, nil
+}
+
+// 按当前数据版本组装待保存的方案，名称为空时按公司、测算类型和当前时间生成
+func newScenario(ctx context.Context, svcCtx *svc.ServiceContext, input scenarioInput) (*model.Scenario, error) {
+	version, err := dataVersion(ctx, svcCtx, input.company)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data version: %v", err)
+	}
+	request, err := json.Marshal(input.request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %v", err)
+	}
+	result, err := json.Marshal(input.result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %v", err)
+	}
+	name := input.name
+	if name == "" {
+		name = fmt.Sprintf("%s %s %s", input.company, input.kind, time.Now().Format(dateTimeLayout))
+	}
+	return &model.Scenario{
+		Name:              name,
+		Kind:              input.kind,
+		Company:           input.company,
+		CalculationMethod: input.method,
+		DataStart:         input.dataStart,
+		DataEnd:           input.dataEnd,
+		DataVersion:       version,
+		Request:           string(request),
+		Result:            string(result),
+		Author:            input.author,
+	}, nil
+}
+
+// 写入方案，返回新方案的 id
+func insertScenario(ctx context.Context, svcCtx *svc.ServiceContext, scenario *model.Scenario) (int64, error) {
+	result, err := svcCtx.ScenarioModel.Insert(ctx, scenario)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert scenario: %v", err)
+	}
+	return result.LastInsertId()
+}
+
+// 保存测算接口的一次调用，保存失败只记录日志并返回 0，不影响测算结果
+func recordScenario(ctx context.Context, svcCtx *svc.ServiceContext, input scenarioInput) int64 {
+	logger := logx.WithContext(ctx)
+	scenario, err := newScenario(ctx, svcCtx, input)
+	if err != nil {
+		logger.Errorf("Failed to save %s scenario: %v", input.kind, err)
+		return 0
+	}
+	id, err := insertScenario(ctx, svcCtx, scenario)
+	if err != nil {
+		logger.Errorf("Failed to save %s scenario: %v", input.kind, err)
+		return 0
+	}
+	logger.Infof("Saved %s scenario %d for company %s", input.kind, id, input.company)
+	return id
+}
+
+func findScenario(ctx context.Context, svcCtx *svc.ServiceContext, id int64) (*model.Scenario, error) {
+	scenario, err := svcCtx.ScenarioModel.FindOne(ctx, id)
+	if err == model.ErrNotFound {
+		return nil, fmt.Errorf("scenario %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// 按保存的参数在当前数据上重新测算，参数中未填入的时段和储能柜参数按当前的电价和产品补齐
+func recalculateScenario(ctx context.Context, svcCtx *svc.ServiceContext, scenario *model.Scenario) (scenarioInput, error) {
+	switch scenario.Kind {
+	case scenarioCapacity, scenarioOptimize:
+		var req types.CapacityConfigRequest
+		if err := json.Unmarshal([]byte(scenario.Request), &req); err != nil {
+			return scenarioInput{}, fmt.Errorf("failed to decode scenario %d request: %v", scenario.Id, err)
+		}
+		var resolved *types.CapacityConfigRequest
+		var result interface{}
+		var err error
+		if scenario.Kind == scenarioCapacity {
+			logic := NewCalculateCapacityLogic(ctx, svcCtx)
+			if resolved, err = logic.resolveRequest(&req); err == nil {
+				result, err = logic.calculate(resolved)
+			}
+		} else {
+			logic := NewOptimizeWindowsLogic(ctx, svcCtx)
+			if resolved, err = logic.resolveRequest(&req); err == nil {
+				result, err = logic.optimize(resolved)
+			}
+		}
+		if err != nil {
+			return scenarioInput{}, err
+		}
+		return capacityScenario(scenario.Kind, resolved, result), nil
+	case scenarioDemand:
+		var req types.DemandRequest
+		if err := json.Unmarshal([]byte(scenario.Request), &req); err != nil {
+			return scenarioInput{}, fmt.Errorf("failed to decode scenario %d request: %v", scenario.Id, err)
+		}
+		logic := NewDemandManagementLogic(ctx, svcCtx)
+		resolved, err := logic.resolveRequest(&req)
+		if err != nil {
+			return scenarioInput{}, err
+		}
+		result, err := logic.evaluate(resolved)
+		if err != nil {
+			return scenarioInput{}, err
+		}
+		return demandScenario(resolved, result), nil
+	default:
+		return scenarioInput{}, fmt.Errorf("unsupported scenario kind: %s", scenario.Kind)
+	}
+}
+
+func scenarioSummaryFromModel(scenario *model.Scenario) types.ScenarioSummary {
+	return types.ScenarioSummary{
+		Id:                scenario.Id,
+		Name:              scenario.Name,
+		Kind:              scenario.Kind,
+		Company:           scenario.Company,
+		CalculationMethod: scenario.CalculationMethod,
+		DataStart:         scenario.DataStart,
+		DataEnd:           scenario.DataEnd,
+		DataVersion:       scenario.DataVersion,
+		Author:            scenario.Author,
+		SourceId:          scenario.SourceId,
+		CreateTime:        scenario.CreateTime.Format(dateTimeLayout),
+	}
+}
+
+// 转换为接口返回格式，测算参数和结果按保存的 JSON 原样返回
+func scenarioFromModel(scenario *model.Scenario, currentVersion int64) *types.Scenario {
+	return &types.Scenario{
+		Id:                 scenario.Id,
+		Name:               scenario.Name,
+		Kind:               scenario.Kind,
+		Company:            scenario.Company,
+		CalculationMethod:  scenario.CalculationMethod,
+		DataStart:          scenario.DataStart,
+		DataEnd:            scenario.DataEnd,
+		DataVersion:        scenario.DataVersion,
+		CurrentDataVersion: currentVersion,
+		Author:             scenario.Author,
+		SourceId:           scenario.SourceId,
+		CreateTime:         scenario.CreateTime.Format(dateTimeLayout),
+		Request:            json.RawMessage(scenario.Request),
+		Result:             json.RawMessage(scenario.Result),
+	}
+}
+
+// 查询方案并附带公司当前的数据版本
+func loadScenario(ctx context.Context, svcCtx *svc.ServiceContext, id int64) (*types.Scenario, error) {
+	scenario, err := findScenario(ctx, svcCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	version, err := dataVersion(ctx, svcCtx, scenario.Company)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data version: %v", err)
+	}
+	return scenarioFromModel(scenario, version), nil
+}
+
+// 逐字段比较两份 JSON，返回取值不同的字段，按字段路径排序
+func diffJSON(left, right string) ([]types.ScenarioDiff, error) {
+	leftFields, err := flattenJSON(left)
+	if err != nil {
+		return nil, err
+	}
+	rightFields, err := flattenJSON(right)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(leftFields))
+	for path := range leftFields {
+		paths = append(paths, path)
+	}
+	for path := range rightFields {
+		if _, ok := leftFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var diffs []types.ScenarioDiff
+	for _, path := range paths {
+		l, lok := leftFields[path]
+		r, rok := rightFields[path]
+		if lok == rok && l == r {
+			continue
+		}
+		diffs = append(diffs, types.ScenarioDiff{Path: path, Left: l, Right: r})
+	}
+	return diffs, nil
+}
+
+// 把 JSON 展开为字段路径到取值的映射，例如 periods[0].start，超过 maxDiffItems 项的列表只记录条数
+func flattenJSON(data string) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data)))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode scenario data: %v", err)
+	}
+	fields := make(map[string]string)
+	flattenValue("", value, fields)
+	return fields, nil
+}
+
+func flattenValue(path string, value interface{}, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if path == "" {
+				flattenValue(key, item, fields)
+			} else {
+				flattenValue(path+"."+key, item, fields)
+			}
+		}
+	case []interface{}:
+		if len(v) > maxDiffItems {
+			fields[path] = fmt.Sprintf("%d 项", len(v))
+			return
+		}
+		for i, item := range v {
+			flattenValue(fmt.Sprintf("%s[%d]", path, i), item, fields)
+		}
+	case json.Number:
+		fields[path] = v.String()
+	case string:
+		fields[path] = v
+	case bool:
+		fields[path] = strconv.FormatBool(v)
+	case nil:
+		fields[path] = "null"
+	}
+}
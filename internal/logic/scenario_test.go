@@ -0,0 +1,93 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"power/internal/types"
+	"power/model"
+)
+
+func TestFlattenJSON(t *testing.T) {
+	long := make([]int, maxDiffItems+1)
+	data, err := json.Marshal(map[string]interface{}{
+		"company":     "zhejiang",
+		"periods":     []map[string]string{{"start": "00:00", "end": "07:45"}},
+		"cabinet":     map[string]interface{}{"pcsPower": 125, "roundTripEfficiency": 0.92},
+		"sensitivity": false,
+		"seasons":     nil,
+		"months":      long,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := flattenJSON(string(data))
+	if err != nil {
+		t.Fatalf("flattenJSON failed: %v", err)
+	}
+	want := map[string]string{
+		"company":                     "zhejiang",
+		"periods[0].start":            "00:00",
+		"periods[0].end":              "07:45",
+		"cabinet.pcsPower":            "125",
+		"cabinet.roundTripEfficiency": "0.92",
+		"sensitivity":                 "false",
+		"seasons":                     "null",
+		// 超过 maxDiffItems 项的列表只记录条数
+		"months": fmt.Sprintf("%d 项", maxDiffItems+1),
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("flattenJSON = %v, want %v", fields, want)
+	}
+
+	if _, err := flattenJSON("{"); err == nil {
+		t.Error("flattenJSON with invalid JSON succeeded, want error")
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	left := `{"company":"zhejiang","tariffId":1,"periods":[{"kind":"charge","start":"00:00","end":"07:45"}],"pcsPower":125}`
+	right := `{"company":"zhejiang","tariffId":2,"periods":[{"kind":"charge","start":"00:00","end":"06:45"},{"kind":"discharge","start":"08:00","end":"11:45"}]}`
+	diffs, err := diffJSON(left, right)
+	if err != nil {
+		t.Fatalf("diffJSON failed: %v", err)
+	}
+	// 按字段路径排序，只有一侧存在的字段另一侧为空
+	want := []types.ScenarioDiff{
+		{Path: "pcsPower", Left: "125", Right: ""},
+		{Path: "periods[0].end", Left: "07:45", Right: "06:45"},
+		{Path: "periods[1].end", Left: "", Right: "11:45"},
+		{Path: "periods[1].kind", Left: "", Right: "discharge"},
+		{Path: "periods[1].start", Left: "", Right: "08:00"},
+		{Path: "tariffId", Left: "1", Right: "2"},
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("diffJSON = %v, want %v", diffs, want)
+	}
+
+	if diffs, err := diffJSON(left, left); err != nil || len(diffs) != 0 {
+		t.Errorf("diffJSON of identical data = (%v, %v), want no differences", diffs, err)
+	}
+}
+
+// 保存方案时填入的储能柜参数在产品修改后仍组装出相同的参数
+func TestCabinetSpecFill(t *testing.T) {
+	product := &model.StorageProduct{EnergyCapacity: 261, PcsPower: 125, RoundTripEfficiency: 0.92, PcsEfficiency: 0.97, DepthOfDischarge: 0.9, AuxiliaryPower: 1.8}
+	spec, err := newCabinetSpec(&types.CapacityConfigRequest{DepthOfDischarge: 0.8, EndSoc: 0.3}, product)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req types.CapacityConfigRequest
+	spec.fill(&req)
+
+	changed := &model.StorageProduct{EnergyCapacity: 372, PcsPower: 186, RoundTripEfficiency: 0.9, PcsEfficiency: 0.95, DepthOfDischarge: 0.95, AuxiliaryPower: 2.5}
+	if got, err := newCabinetSpec(&req, changed); err != nil || got != spec {
+		t.Errorf("newCabinetSpec of filled request = (%+v, %v), want %+v", got, err, spec)
+	}
+	if got, err := productCabinetSpec(changed, spec.params()); err != nil || got.dischargeCapacity != spec.dischargeCapacity ||
+		got.roundTripEfficiency != spec.roundTripEfficiency || got.depthOfDischarge != spec.depthOfDischarge || got.pcsPower != spec.pcsPower {
+		t.Errorf("productCabinetSpec of filled params = (%+v, %v), want %+v", got, err, spec)
+	}
+}
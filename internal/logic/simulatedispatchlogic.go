@@ -0,0 +1,140 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SimulateDispatchLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSimulateDispatchLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SimulateDispatchLogic {
+	return &SimulateDispatchLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *SimulateDispatchLogic) SimulateDispatch(req *types.SimulationRequest) (*types.SimulationResponse, error) {
+	l.Logger.Infof("Simulating dispatch: company=%s, startTime=%s, endTime=%s, strategy=%s", req.Company, req.StartTime, req.EndTime, req.Strategy)
+
+	battery, err := batteryFromConfig(req.Battery)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := newScheduleStrategy(req.Periods)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := queryPowerSeries(l.ctx, l.svcCtx, req.StartTime, req.EndTime, req.Company)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := simulateDispatch(battery, loadPoints(data, req.MeterMultiplier), strategy, req.TransformerCapacity*req.PowerFactor)
+
+	resp := &types.SimulationResponse{
+		Slots: make([]types.DispatchSlot, 0, len(slots)),
+	}
+	for _, slot := range slots {
+		resp.Slots = append(resp.Slots, types.DispatchSlot{
+			Time:           slot.time.Format(dateTimeLayout),
+			Load:           slot.load,
+			ChargePower:    slot.charge,
+			DischargePower: slot.discharge,
+			Soc:            slot.soc,
+			NetLoad:        slot.netLoad,
+		})
+	}
+
+	usableEnergy := battery.energy * (battery.maxSoc - battery.minSoc)
+	for _, day := range summarizeDispatchDays(slots) {
+		daily := types.DailyThroughput{
+			Date:            day.date,
+			ChargeEnergy:    day.charge,
+			DischargeEnergy: day.discharge,
+		}
+		if usableEnergy > 0 {
+			daily.Cycles = day.discharge / usableEnergy
+		}
+		resp.Days = append(resp.Days, daily)
+		resp.TotalChargeEnergy += day.charge
+		resp.TotalDischargeEnergy += day.discharge
+		resp.EquivalentCycles += daily.Cycles
+	}
+
+	l.Logger.Infof("Simulated %d slots: charged %.2f kWh, discharged %.2f kWh", len(slots), resp.TotalChargeEnergy, resp.TotalDischargeEnergy)
+	return resp, nil
+}
+
+// 校验储能系统参数并转换为电池模型
+func batteryFromConfig(cfg types.BatteryConfig) (batteryModel, error) {
+	if cfg.EnergyCapacity <= 0 || cfg.PowerRating <= 0 {
+		return batteryModel{}, fmt.Errorf("battery energy capacity and power rating must be positive")
+	}
+	if cfg.MinSoc < 0 || cfg.MaxSoc > 1 || cfg.MinSoc >= cfg.MaxSoc {
+		return batteryModel{}, fmt.Errorf("invalid battery SOC range [%v, %v]", cfg.MinSoc, cfg.MaxSoc)
+	}
+	if cfg.InitialSoc < 0 || cfg.InitialSoc > cfg.MaxSoc {
+		return batteryModel{}, fmt.Errorf("initial SOC %v must be in [0, %v]", cfg.InitialSoc, cfg.MaxSoc)
+	}
+	if cfg.RoundTripEfficiency <= 0 || cfg.RoundTripEfficiency > 1 {
+		return batteryModel{}, fmt.Errorf("invalid round-trip efficiency %v", cfg.RoundTripEfficiency)
+	}
+	if cfg.AuxiliaryPower < 0 {
+		return batteryModel{}, fmt.Errorf("auxiliary power must not be negative")
+	}
+	return batteryModel{
+		energy:              cfg.EnergyCapacity,
+		power:               cfg.PowerRating,
+		minSoc:              cfg.MinSoc,
+		maxSoc:              cfg.MaxSoc,
+		initialSoc:          math.Max(cfg.InitialSoc, cfg.MinSoc),
+		roundTripEfficiency: cfg.RoundTripEfficiency,
+		auxiliaryPower:      cfg.AuxiliaryPower,
+	}, nil
+}
+
+// 按充放电时段构造时刻表调度策略，时段使用当天时刻
+func newScheduleStrategy(periods []types.CapacityPeriod) (scheduleStrategy, error) {
+	var strategy scheduleStrategy
+	for i, p := range periods {
+		window, err := parseClockWindow(p.Start, p.End)
+		if err != nil {
+			return scheduleStrategy{}, fmt.Errorf("invalid period %d: %v", i, err)
+		}
+		switch p.Kind {
+		case periodCharge:
+			strategy.chargeWindows = append(strategy.chargeWindows, window)
+		case periodDischarge:
+			strategy.dischargeWindows = append(strategy.dischargeWindows, window)
+		default:
+			return scheduleStrategy{}, fmt.Errorf("period %d has unsupported kind: %s", i, p.Kind)
+		}
+	}
+	if len(strategy.chargeWindows) == 0 || len(strategy.dischargeWindows) == 0 {
+		return scheduleStrategy{}, fmt.Errorf("schedule strategy needs at least one charge and one discharge period")
+	}
+	return strategy, nil
+}
+
+// 将功率数据乘以电表倍率转换为负荷序列
+func loadPoints(data []model.PowerData, meterMultiplier float64) []loadPoint {
+	points := make([]loadPoint, len(data))
+	for i, d := range data {
+		points[i] = loadPoint{time: d.DataTime, load: d.Power * meterMultiplier}
+	}
+	return points
+}
@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SizingCurveLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSizingCurveLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SizingCurveLogic {
+	return &SizingCurveLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// 容量曲线评估的最大台数
+const maxSizingCabinets = 500
+
+func (l *SizingCurveLogic) SizingCurve(req *types.SizingCurveRequest) (*types.SizingCurveResponse, error) {
+	l.Logger.Infof("Evaluating sizing curve: company=%s, tariff=%d, product=%d, maxCabinets=%d", req.Company, req.TariffId, req.ProductId, req.MaxCabinetCount)
+
+	if req.MaxCabinetCount <= 0 || req.MaxCabinetCount > maxSizingCabinets {
+		return nil, fmt.Errorf("maxCabinetCount must be in [1, %d], got %d", maxSizingCabinets, req.MaxCabinetCount)
+	}
+	if req.UnitCost < 0 || req.FixedCost < 0 || req.OmCostPerCabinet < 0 {
+		return nil, fmt.Errorf("capital and O&M costs must not be negative")
+	}
+	inv := investment{
+		lifeYears:       req.LifeYears,
+		discountRate:    req.DiscountRate,
+		degradationRate: req.DegradationRate,
+	}
+	if err := inv.validate(); err != nil {
+		return nil, err
+	}
+
+	product, err := findProduct(l.ctx, l.svcCtx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := productCabinetSpec(product, req.Cabinet)
+	if err != nil {
+		return nil, err
+	}
+	unitCost := defaultIfZero(req.UnitCost, product.UnitCost)
+
+	startTime, endTime, err := parseTimeRange(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := loadTariffSchedules(l.ctx, l.svcCtx, req.TariffId, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	data, err := queryPowerSeries(l.ctx, l.svcCtx, req.StartTime, req.EndTime, req.Company)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no power data for company %s between %s and %s", req.Company, req.StartTime, req.EndTime)
+	}
+	points := loadPoints(data, req.MeterMultiplier)
+	strategy := tariffStrategy{schedules: schedules}
+	gridLimit := req.TransformerCapacity * req.PowerFactor
+
+	// 每个台数按同一份负荷和电价调度，收益按模拟天数折算为全年
+	resp := &types.SizingCurveResponse{
+		Points: make([]types.SizingPoint, 0, req.MaxCabinetCount),
+	}
+	var previousRevenue float64
+	for count := 1; count <= req.MaxCabinetCount; count++ {
+		battery := spec.battery(count)
+		value := priceDispatch(simulateDispatch(battery, points, strategy, gridLimit), schedules)
+		annual := value.annualized()
+		resp.SimulatedDays = value.days
+
+		inv.capitalCost = req.FixedCost + unitCost*float64(count)
+		inv.annualOmCost = req.OmCostPerCabinet * float64(count)
+		result := evaluateInvestment(inv, annual.revenue())
+
+		point := types.SizingPoint{
+			CabinetCount:          count,
+			BatteryEnergy:         battery.energy,
+			AnnualDischargeEnergy: annual.dischargeEnergy,
+			AnnualRevenue:         annual.revenue(),
+			MarginalRevenue:       annual.revenue() - previousRevenue,
+			CapitalCost:           inv.capitalCost,
+			SimplePayback:         result.simplePayback,
+			Npv:                   result.npv,
+			Irr:                   result.irr,
+			IrrAvailable:          result.irrAvailable,
+		}
+		if usable := battery.energy * (battery.maxSoc - battery.minSoc); usable > 0 {
+			point.Utilization = annual.dischargeEnergy / (usable * 365)
+		}
+		resp.Points = append(resp.Points, point)
+		previousRevenue = annual.revenue()
+
+		if resp.BestNpvCount == 0 || result.npv > resp.BestNpv {
+			resp.BestNpvCount = count
+			resp.BestNpv = result.npv
+		}
+	}
+
+	l.Logger.Infof("Sizing curve over %d days: best NPV %.2f at %d cabinets", resp.SimulatedDays, resp.BestNpv, resp.BestNpvCount)
+	return resp, nil
+}
@@ -0,0 +1,79 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+)
+
+// 校验产品参数
+func validateProduct(req *types.ProductRequest) error {
+	if req.Name == "" {
+		return fmt.Errorf("product name is required")
+	}
+	if req.EnergyCapacity <= 0 || req.PcsPower <= 0 {
+		return fmt.Errorf("product energy capacity and PCS power must be positive")
+	}
+	for name, value := range map[string]float64{
+		"roundTripEfficiency": req.RoundTripEfficiency,
+		"pcsEfficiency":       req.PcsEfficiency,
+		"depthOfDischarge":    req.DepthOfDischarge,
+	} {
+		if value <= 0 || value > 1 {
+			return fmt.Errorf("%s must be in (0, 1], got %v", name, value)
+		}
+	}
+	if req.AuxiliaryPower < 0 || req.Footprint < 0 || req.UnitCost < 0 {
+		return fmt.Errorf("auxiliary power, footprint and unit cost must not be negative")
+	}
+	return nil
+}
+
+// 将请求中的产品参数写入数据库模型
+func applyProductRequest(product *model.StorageProduct, req *types.ProductRequest) {
+	product.Name = req.Name
+	product.Manufacturer = req.Manufacturer
+	product.EnergyCapacity = req.EnergyCapacity
+	product.PcsPower = req.PcsPower
+	product.RoundTripEfficiency = req.RoundTripEfficiency
+	product.PcsEfficiency = req.PcsEfficiency
+	product.DepthOfDischarge = req.DepthOfDischarge
+	product.AuxiliaryPower = req.AuxiliaryPower
+	product.Footprint = req.Footprint
+	product.UnitCost = req.UnitCost
+}
+
+// 将数据库中的产品转换为接口返回格式
+func productFromModel(product *model.StorageProduct) *types.Product {
+	return &types.Product{
+		Id:                  product.Id,
+		Name:                product.Name,
+		Manufacturer:        product.Manufacturer,
+		EnergyCapacity:      product.EnergyCapacity,
+		PcsPower:            product.PcsPower,
+		CRate:               product.PcsPower / product.EnergyCapacity,
+		RoundTripEfficiency: product.RoundTripEfficiency,
+		PcsEfficiency:       product.PcsEfficiency,
+		DepthOfDischarge:    product.DepthOfDischarge,
+		AuxiliaryPower:      product.AuxiliaryPower,
+		Footprint:           product.Footprint,
+		UnitCost:            product.UnitCost,
+		CreateTime:          product.CreateTime.Format(dateTimeLayout),
+		UpdateTime:          product.UpdateTime.Format(dateTimeLayout),
+	}
+}
+
+// 按 id 查询产品，不存在时返回明确的错误信息
+func findProduct(ctx context.Context, svcCtx *svc.ServiceContext, id int64) (*model.StorageProduct, error) {
+	product, err := svcCtx.ProductModel.FindOne(ctx, id)
+	if err == model.ErrNotFound {
+		return nil, fmt.Errorf("product %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
@@ -0,0 +1,324 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+)
+
+// 分时电价时段类型
+const (
+	tariffCritical = "critical"
+	tariffPeak     = "peak"
+	tariffFlat     = "flat"
+	tariffValley   = "valley"
+)
+
+// 某个月份的分时电价表：每个 15 分钟时刻的时段类型和电价
+type tariffSchedule struct {
+	kinds  [slotsPerDay]string
+	prices [slotsPerDay]float64
+}
+
+// 按月份展开电价时段，返回 1-12 月的电价表（下标为月份减 1），每个月的时段必须覆盖全天且互不重叠
+func tariffSchedules(periods []types.TariffPeriod) ([12]tariffSchedule, error) {
+	var schedules [12]tariffSchedule
+	if len(periods) == 0 {
+		return schedules, fmt.Errorf("tariff must have at least one period")
+	}
+	for i, p := range periods {
+		switch p.Kind {
+		case tariffCritical, tariffPeak, tariffFlat, tariffValley:
+		default:
+			return schedules, fmt.Errorf("tariff period %d has unsupported kind: %s", i, p.Kind)
+		}
+		if p.Price < 0 {
+			return schedules, fmt.Errorf("tariff period %d has negative price", i)
+		}
+		window, err := parseClockWindow(p.Start, p.End)
+		if err != nil {
+			return schedules, fmt.Errorf("invalid tariff period %d: %v", i, err)
+		}
+		// 开始和结束时刻相同表示全天，例如 00:00 至 00:00
+		allDay := window.startSlot == window.endSlot
+
+		months := p.Months
+		if len(months) == 0 {
+			months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		}
+		for _, month := range months {
+			if month < 1 || month > 12 {
+				return schedules, fmt.Errorf("tariff period %d has invalid month %d", i, month)
+			}
+			schedule := &schedules[month-1]
+			for slot := 0; slot < slotsPerDay; slot++ {
+				if !allDay && !window.activeAt(slot) {
+					continue
+				}
+				if schedule.kinds[slot] != "" {
+					return schedules, fmt.Errorf("tariff period %d overlaps another period in month %d at %s", i, month, slotLabel(slot))
+				}
+				schedule.kinds[slot] = p.Kind
+				schedule.prices[slot] = p.Price
+			}
+		}
+	}
+
+	for month, schedule := range schedules {
+		for slot, kind := range schedule.kinds {
+			if kind == "" {
+				return schedules, fmt.Errorf("tariff does not cover %s in month %d", slotLabel(slot), month+1)
+			}
+		}
+	}
+	return schedules, nil
+}
+
+// 校验请求并转换为数据库模型
+func tariffFromRequest(req *types.TariffRequest) (*model.Tariff, []model.TariffPeriod, error) {
+	if req.Name == "" || req.Province == "" {
+		return nil, nil, fmt.Errorf("tariff name and province are required")
+	}
+	if req.DemandPrice < 0 || req.CapacityPrice < 0 {
+		return nil, nil, fmt.Errorf("demandPrice and capacityPrice must not be negative")
+	}
+	if _, err := tariffSchedules(req.Periods); err != nil {
+		return nil, nil, err
+	}
+	location, err := loadLocation()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load location: %v", err)
+	}
+	effectiveFrom, err := time.ParseInLocation(dateLayout, req.EffectiveFrom, location)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid effective date %s: %v", req.EffectiveFrom, err)
+	}
+	var effectiveTo sql.NullTime
+	if req.EffectiveTo != "" {
+		effectiveTo.Time, err = time.ParseInLocation(dateLayout, req.EffectiveTo, location)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid expiry date %s: %v", req.EffectiveTo, err)
+		}
+		if effectiveTo.Time.Before(effectiveFrom) {
+			return nil, nil, fmt.Errorf("expiry date %s is before effective date %s", req.EffectiveTo, req.EffectiveFrom)
+		}
+		effectiveTo.Valid = true
+	}
+
+	tariff := &model.Tariff{
+		Id:            req.Id,
+		Name:          req.Name,
+		Province:      req.Province,
+		VoltageLevel:  req.VoltageLevel,
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   effectiveTo,
+		Description:   req.Description,
+		DemandPrice:   req.DemandPrice,
+		CapacityPrice: req.CapacityPrice,
+	}
+	periods := make([]model.TariffPeriod, len(req.Periods))
+	for i, p := range req.Periods {
+		months := make([]string, len(p.Months))
+		for j, month := range p.Months {
+			months[j] = strconv.Itoa(month)
+		}
+		periods[i] = model.TariffPeriod{
+			Kind:      p.Kind,
+			Months:    strings.Join(months, ","),
+			StartTime: p.Start,
+			EndTime:   p.End,
+			Price:     p.Price,
+		}
+	}
+	return tariff, periods, nil
+}
+
+// 将数据库中的电价时段转换为接口格式
+func tariffPeriodsFromModel(periods []model.TariffPeriod) []types.TariffPeriod {
+	resp := make([]types.TariffPeriod, len(periods))
+	for i, p := range periods {
+		var months []int
+		for _, field := range strings.Split(p.Months, ",") {
+			if month, err := strconv.Atoi(strings.TrimSpace(field)); err == nil {
+				months = append(months, month)
+			}
+		}
+		resp[i] = types.TariffPeriod{
+			Kind:   p.Kind,
+			Months: months,
+			Start:  p.StartTime,
+			End:    p.EndTime,
+			Price:  p.Price,
+		}
+	}
+	return resp
+}
+
+// 将数据库中的电价转换为接口返回格式
+func tariffFromModel(tariff *model.Tariff, periods []model.TariffPeriod) *types.Tariff {
+	resp := &types.Tariff{
+		Id:            tariff.Id,
+		Name:          tariff.Name,
+		Province:      tariff.Province,
+		VoltageLevel:  tariff.VoltageLevel,
+		EffectiveFrom: tariff.EffectiveFrom.Format(dateLayout),
+		Description:   tariff.Description,
+		DemandPrice:   tariff.DemandPrice,
+		CapacityPrice: tariff.CapacityPrice,
+		Periods:       tariffPeriodsFromModel(periods),
+		CreateTime:    tariff.CreateTime.Format(dateTimeLayout),
+		UpdateTime:    tariff.UpdateTime.Format(dateTimeLayout),
+	}
+	if tariff.EffectiveTo.Valid {
+		resp.EffectiveTo = tariff.EffectiveTo.Time.Format(dateLayout)
+	}
+	return resp
+}
+
+// 按 id 查询电价及其时段，不存在时返回明确的错误信息
+func findTariff(ctx context.Context, svcCtx *svc.ServiceContext, id int64) (*model.Tariff, []model.TariffPeriod, error) {
+	tariff, err := svcCtx.TariffModel.FindOne(ctx, id)
+	if err == model.ErrNotFound {
+		return nil, nil, fmt.Errorf("tariff %d not found", id)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	periods, err := svcCtx.TariffPeriodModel.FindByTariffId(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tariff, periods, nil
+}
+
+// 校验数据的起止时间在电价的有效期内，失效日期当天仍按该电价计算
+func checkTariffEffective(tariff *model.Tariff, start, end time.Time) error {
+	first, last := start.Format(dateLayout), end.Format(dateLayout)
+	if from := tariff.EffectiveFrom.Format(dateLayout); first < from {
+		return fmt.Errorf("tariff %d is effective from %s, but the data starts on %s", tariff.Id, from, first)
+	}
+	if tariff.EffectiveTo.Valid {
+		if to := tariff.EffectiveTo.Time.Format(dateLayout); last > to {
+			return fmt.Errorf("tariff %d expired on %s, but the data ends on %s", tariff.Id, to, last)
+		}
+	}
+	return nil
+}
+
+// 查询电价并展开为 1-12 月的电价表，start、end 为使用该电价的数据起止时间，需在电价的有效期内
+func loadTariffSchedules(ctx context.Context, svcCtx *svc.ServiceContext, id int64, start, end time.Time) ([12]tariffSchedule, error) {
+	tariff, periods, err := findTariff(ctx, svcCtx, id)
+	if err != nil {
+		return [12]tariffSchedule{}, err
+	}
+	if err := checkTariffEffective(tariff, start, end); err != nil {
+		return [12]tariffSchedule{}, err
+	}
+	schedules, err := tariffSchedules(tariffPeriodsFromModel(periods))
+	if err != nil {
+		return schedules, fmt.Errorf("tariff %d is invalid: %v", id, err)
+	}
+	return schedules, nil
+}
+
+// 电价时段对应的储能动作：低谷充电，高峰和尖峰放电，平段不动作
+func tariffAction(kind string) string {
+	switch kind {
+	case tariffValley:
+		return periodCharge
+	case tariffPeak, tariffCritical:
+		return periodDischarge
+	default:
+		return ""
+	}
+}
+
+// 电价表中动作相同的连续时段，end 为结束时刻序号（不含），跨零点时超过 96
+type tariffBlock struct {
+	action     string
+	start, end int
+}
+
+// 按充放电时段转换，起止时刻为 [start, end) 内的子区间
+// 测算时段的起止时刻均包含在内，结束时刻取子区间内最后一个时刻，避免多算下一个电价时段的时刻
+func (b tariffBlock) period(start, end int) types.CapacityPeriod {
+	return types.CapacityPeriod{
+		Kind:  b.action,
+		Start: slotLabel(start % slotsPerDay),
+		End:   slotLabel((end - 1) % slotsPerDay),
+	}
+}
+
+// 按充放电动作将当天划分为连续时段，平段不动作不返回
+// 以包含零点或零点后最早开始的充电时段作为第一个时段
+func (s tariffSchedule) blocks() ([]tariffBlock, error) {
+	// 从一个动作发生变化的时刻开始遍历，保证跨零点的时段不被拆开
+	start := -1
+	for slot := 0; slot < slotsPerDay; slot++ {
+		if tariffAction(s.kinds[slot]) != tariffAction(s.kinds[(slot+slotsPerDay-1)%slotsPerDay]) {
+			start = slot
+			break
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("tariff has no alternating charge and discharge periods")
+	}
+
+	var runs []tariffBlock
+	for i := 0; i < slotsPerDay; i++ {
+		slot := start + i
+		action := tariffAction(s.kinds[slot%slotsPerDay])
+		if len(runs) > 0 && runs[len(runs)-1].action == action {
+			runs[len(runs)-1].end = slot + 1
+			continue
+		}
+		runs = append(runs, tariffBlock{action: action, start: slot, end: slot + 1})
+	}
+
+	first := -1
+	for i, r := range runs {
+		if r.action != periodCharge {
+			continue
+		}
+		if r.start%slotsPerDay > (r.end-1)%slotsPerDay || r.start%slotsPerDay == 0 {
+			first = i
+			break
+		}
+		if first < 0 || r.start%slotsPerDay < runs[first].start%slotsPerDay {
+			first = i
+		}
+	}
+	if first < 0 {
+		return nil, fmt.Errorf("tariff has no valley period to charge in")
+	}
+
+	var blocks []tariffBlock
+	for i := range runs {
+		r := runs[(first+i)%len(runs)]
+		if r.action == "" {
+			continue
+		}
+		blocks = append(blocks, r)
+	}
+	return blocks, nil
+}
+
+// 按电价表生成一天的充放电时段，相邻的同类时段合并；从跨零点（或零点后第一个）的充电时段开始排列
+func (s tariffSchedule) capacityPeriods() ([]types.CapacityPeriod, error) {
+	blocks, err := s.blocks()
+	if err != nil {
+		return nil, err
+	}
+	periods := make([]types.CapacityPeriod, len(blocks))
+	for i, b := range blocks {
+		periods[i] = b.period(b.start, b.end)
+	}
+	return periods, nil
+}
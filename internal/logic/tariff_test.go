@@ -0,0 +1,66 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+
+	"power/internal/types"
+)
+
+func TestTariffCapacityPeriods(t *testing.T) {
+	schedules, err := tariffSchedules(optimizerTariffPeriods)
+	if err != nil {
+		t.Fatalf("tariffSchedules failed: %v", err)
+	}
+	periods, err := schedules[0].capacityPeriods()
+	if err != nil {
+		t.Fatalf("capacityPeriods failed: %v", err)
+	}
+	// 结束时刻为电价时段内最后一个时刻，不包含下一个电价时段的开始时刻
+	want := []types.CapacityPeriod{
+		{Kind: periodCharge, Start: "00:00", End: "07:45"},
+		{Kind: periodDischarge, Start: "08:00", End: "11:45"},
+		{Kind: periodDischarge, Start: "17:00", End: "21:45"},
+	}
+	if !reflect.DeepEqual(periods, want) {
+		t.Fatalf("capacityPeriods = %v, want %v", periods, want)
+	}
+
+	blocks, err := schedules[0].blocks()
+	if err != nil {
+		t.Fatalf("blocks failed: %v", err)
+	}
+	for i, p := range periods {
+		window, err := parseClockWindow(p.Start, p.End)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := window.slots(); got != blocks[i].end-blocks[i].start {
+			t.Errorf("period %v covers %d slots, want %d", p, got, blocks[i].end-blocks[i].start)
+		}
+	}
+}
+
+func TestTariffSchedulesAllDay(t *testing.T) {
+	schedules, err := tariffSchedules([]types.TariffPeriod{
+		{Kind: tariffFlat, Start: "00:00", End: "00:00", Price: 0.6, Months: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+		{Kind: tariffValley, Start: "22:00", End: "08:00", Price: 0.3, Months: []int{12}},
+		{Kind: tariffPeak, Start: "08:00", End: "22:00", Price: 1.1, Months: []int{12}},
+	})
+	if err != nil {
+		t.Fatalf("tariffSchedules failed: %v", err)
+	}
+	for slot := 0; slot < slotsPerDay; slot++ {
+		if schedules[0].kinds[slot] != tariffFlat || schedules[0].prices[slot] != 0.6 {
+			t.Fatalf("slot %s in January = %s %v, want flat 0.6", slotLabel(slot), schedules[0].kinds[slot], schedules[0].prices[slot])
+		}
+	}
+
+	// 全天时段与其他时段重叠
+	if _, err := tariffSchedules([]types.TariffPeriod{
+		{Kind: tariffFlat, Start: "00:00", End: "00:00", Price: 0.6},
+		{Kind: tariffPeak, Start: "08:00", End: "12:00", Price: 1.1},
+	}); err == nil {
+		t.Error("tariffSchedules with an all-day period overlapping another period succeeded, want error")
+	}
+}
@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"math"
+
+	"power/internal/types"
+)
+
+// 充电时变压器允许的负荷 (kW)，额定容量乘以功率因数和短时过载系数
+func transformerLimit(req *types.CapacityConfigRequest) float64 {
+	return req.TransformerCapacity * req.PowerFactor * defaultIfZero(req.OverloadFactor, 1)
+}
+
+// 按 cabinetCount 台储能柜逐时刻校验充电时段的变压器负荷，返回超出允许负荷的时刻
+// 每台储能柜按均匀充满充电时段的功率充电，不超过 PCS 额定功率
+func overloadSlots(req *types.CapacityConfigRequest, spec cabinetSpec, periods []capacityPeriod, results []periodResult, cabinetCount int) []types.OverloadSlot {
+	limit := transformerLimit(req)
+	var slots []types.OverloadSlot
+	for i, period := range periods {
+		if !period.charge || results[i].hours == 0 {
+			continue
+		}
+		chargePower := results[i].cabinetEnergy / results[i].hours
+		if spec.pcsPower > 0 {
+			chargePower = math.Min(chargePower, spec.pcsPower)
+		}
+		chargePower *= float64(cabinetCount)
+
+		for _, d := range results[i].data {
+			load := d.Power * req.MeterMultiplier
+			if gridLoad := load + chargePower; gridLoad > limit {
+				slots = append(slots, types.OverloadSlot{
+					Time:        d.Time,
+					Load:        load,
+					ChargePower: chargePower,
+					GridLoad:    gridLoad,
+					Limit:       limit,
+					Excess:      gridLoad - limit,
+				})
+			}
+		}
+	}
+	return slots
+}
@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"power/internal/svc"
+	"power/internal/types"
+	"power/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type TypicalCurveLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewTypicalCurveLogic(ctx context.Context, svcCtx *svc.ServiceContext) *TypicalCurveLogic {
+	return &TypicalCurveLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// 按时段分组的功率值，以及分组内出现过的日期
+type curveGroup struct {
+	slots [slotsPerDay][]float64
+	days  map[string]struct{}
+}
+
+func (l *TypicalCurveLogic) TypicalCurve(req *types.TypicalCurveRequest) (*types.TypicalCurveResponse, error) {
+	l.Logger.Infof("Building typical curve: company=%s, startTime=%s, endTime=%s, method=%s, groupBy=%s",
+		req.Company, req.StartTime, req.EndTime, req.Method, req.GroupBy)
+
+	// 提前校验统计方法，避免查询数据后才发现方法不支持
+	if _, err := calculateStatistic(req.Method, nil); err != nil {
+		return nil, err
+	}
+
+	data, err := queryPowerSeries(l.ctx, l.svcCtx, req.StartTime, req.EndTime, req.Company)
+	if err != nil {
+		return nil, err
+	}
+
+	// 将每个数据点按分组和时刻归类
+	groups := make(map[string]*curveGroup)
+	for _, d := range data {
+		key := curveGroupKey(d, req.GroupBy)
+		group, ok := groups[key]
+		if !ok {
+			group = &curveGroup{days: make(map[string]struct{})}
+			groups[key] = group
+		}
+		slot := slotOfDay(d.DataTime)
+		group.slots[slot] = append(group.slots[slot], d.Power)
+		group.days[d.DataTime.Format(dateLayout)] = struct{}{}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	curves := make([]types.TypicalCurve, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		points := make([]types.CurvePoint, slotsPerDay)
+		for slot, powers := range group.slots {
+			point := types.CurvePoint{
+				Time:  slotLabel(slot),
+				Count: len(powers),
+			}
+			if len(powers) > 0 {
+				point.Value, err = calculateStatistic(req.Method, powers)
+				if err != nil {
+					return nil, err
+				}
+				point.Min, point.Max = math.Inf(1), math.Inf(-1)
+				for _, power := range powers {
+					point.Min = math.Min(point.Min, power)
+					point.Max = math.Max(point.Max, power)
+				}
+			}
+			points[slot] = point
+		}
+		curves = append(curves, types.TypicalCurve{
+			Group:    key,
+			DayCount: len(group.days),
+			Points:   points,
+		})
+	}
+
+	l.Logger.Infof("Built %d typical curves from %d data points", len(curves), len(data))
+	return &types.TypicalCurveResponse{
+		Curves: curves,
+	}, nil
+}
+
+// 根据分组方式确定数据点所属的分组
+func curveGroupKey(d model.PowerData, groupBy string) string {
+	switch groupBy {
+	case "weekday":
+		if weekday := d.DataTime.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			return "weekend"
+		}
+		return "weekday"
+	case "month":
+		return d.DataTime.Format("2006-01")
+	default:
+		return "all"
+	}
+}
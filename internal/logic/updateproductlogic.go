@@ -0,0 +1,47 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateProductLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateProductLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateProductLogic {
+	return &UpdateProductLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateProductLogic) UpdateProduct(req *types.ProductRequest) (*types.Product, error) {
+	if err := validateProduct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := findProduct(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	applyProductRequest(product, req)
+	if err := l.svcCtx.ProductModel.Update(l.ctx, product); err != nil {
+		l.Logger.Error("Failed to update product: ", err)
+		return nil, err
+	}
+
+	l.Logger.Infof("Updated product %d: %s", req.Id, req.Name)
+	updated, err := findProduct(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return productFromModel(updated), nil
+}
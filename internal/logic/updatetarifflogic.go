@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"context"
+
+	"power/internal/svc"
+	"power/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateTariffLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateTariffLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateTariffLogic {
+	return &UpdateTariffLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateTariffLogic) UpdateTariff(req *types.TariffRequest) (*types.Tariff, error) {
+	tariff, periods, err := tariffFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := findTariff(l.ctx, l.svcCtx, req.Id); err != nil {
+		return nil, err
+	}
+	if err := l.svcCtx.TariffModel.UpdateWithPeriods(l.ctx, tariff, periods); err != nil {
+		l.Logger.Error("Failed to update tariff: ", err)
+		return nil, err
+	}
+
+	l.Logger.Infof("Updated tariff %d: %s", req.Id, req.Name)
+	updated, updatedPeriods, err := findTariff(l.ctx, l.svcCtx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return tariffFromModel(updated, updatedPeriods), nil
+}
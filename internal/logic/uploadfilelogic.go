@@ -75,7 +75,7 @@ func (l *UploadFileLogic) UploadFile(req *types.UploadRequest) (*types.UploadRes
 	}
 
 	// 使用背景上下文启动异步任务
-	go l.processFileAsync(context.Background(), tempFile.Name(), req.Company)
+	go l.processFileAsync(context.Background(), tempFile.Name(), filename, req.Company)
 
 	// 返回上传成功的响应
 	return &types.UploadResponse{
@@ -114,7 +114,7 @@ func (l *UploadFileLogic) convertCSVToExcel(csvFile multipart.File, excelFilePat
 	return nil
 }
 
-func (l *UploadFileLogic) processFileAsync(ctx context.Context, filePath, company string) {
+func (l *UploadFileLogic) processFileAsync(ctx context.Context, filePath, fileName, company string) {
 	//打印 company字段的值
 	logx.Infof("Processing file for company: %s", company)
 
@@ -134,23 +134,40 @@ func (l *UploadFileLogic) processFileAsync(ctx context.Context, filePath, compan
 		return
 	}
 
+	var stored []model.PowerData
 	if len(rows) > 0 && len(rows[0]) > 0 && rows[0][0] == "数据日期" {
 		// 如果 Excel 文件第1行第1列为 "数据日期"，使用第一种逻辑
-		l.processFirstFormat(ctx, rows, company)
+		stored = l.processFirstFormat(ctx, rows, company)
 	} else {
 		// 使用第二种逻辑处理
-		l.processSecondFormat(ctx, rows, company)
+		stored = l.processSecondFormat(ctx, rows, company)
+	}
+
+	// 记录本次上传的入库结果，供公司数据覆盖情况查询使用
+	days := make(map[string]struct{})
+	for _, data := range stored {
+		days[data.DataTime.Format("2006-01-02")] = struct{}{}
+	}
+	_, err = l.svcCtx.UploadLogModel.Insert(ctx, &model.UploadLog{
+		Company:  company,
+		FileName: fileName,
+		RowCount: int64(len(stored)),
+		DayCount: int64(len(days)),
+	})
+	if err != nil {
+		logx.Errorf("Failed to record upload log: %v", err)
 	}
 }
 
-func (l *UploadFileLogic) processFirstFormat(ctx context.Context, rows [][]string, company string) {
+// 返回成功入库的数据
+func (l *UploadFileLogic) processFirstFormat(ctx context.Context, rows [][]string, company string) []model.PowerData {
 	validData := make(map[string][]model.PowerData) // 使用 map 来存储每个日期对应的有效数据
 
 	// 加载时区
 	location, err := time.LoadLocation("Asia/Shanghai")
 	if err != nil {
 		logx.Errorf("Failed to load location: %v", err)
-		return
+		return nil
 	}
 
 	for i, row := range rows {
@@ -210,19 +227,23 @@ func (l *UploadFileLogic) processFirstFormat(ctx context.Context, rows [][]strin
 	})
 
 	// 将清洗后的数据存入MySQL数据库
+	var stored []model.PowerData
 	for _, data := range allReadings {
 		_, err := l.svcCtx.Model.Insert(ctx, &data)
 		if err != nil {
 			logx.Errorf("Failed to store data into database: %v", err)
 			continue
 		}
+		stored = append(stored, data)
 		logx.Infof("Inserted data: Time=%s, Power=%f, Company=%s", data.DataTime, data.Power, data.Company)
 	}
 
 	logx.Info("文件上传并处理成功")
+	return stored
 }
 
-func (l *UploadFileLogic) processSecondFormat(ctx context.Context, rows [][]string, company string) {
+// 返回成功入库的数据
+func (l *UploadFileLogic) processSecondFormat(ctx context.Context, rows [][]string, company string) []model.PowerData {
 	// 查找日期和功率的列
 	dateCol, powerCol := -1, -1
 	for index, cell := range rows[0] {
@@ -236,14 +257,14 @@ func (l *UploadFileLogic) processSecondFormat(ctx context.Context, rows [][]stri
 
 	if dateCol == -1 || powerCol == -1 {
 		logx.Errorf("Excel文件中未找到所需的列")
-		return
+		return nil
 	}
 
 	// 加载时区
 	location, err := time.LoadLocation("Asia/Shanghai")
 	if err != nil {
 		logx.Errorf("Failed to load location: %v", err)
-		return
+		return nil
 	}
 
 	var cleanedData []model.PowerData
@@ -320,14 +341,17 @@ func (l *UploadFileLogic) processSecondFormat(ctx context.Context, rows [][]stri
 	})
 
 	// 将清洗后的数据存入MySQL数据库
+	var stored []model.PowerData
 	for _, data := range cleanedData {
 		_, err := l.svcCtx.Model.Insert(ctx, &data)
 		if err != nil {
 			logx.Errorf("Failed to store data into database: %v", err)
 			continue
 		}
+		stored = append(stored, data)
 		logx.Infof("Inserted data: Time=%s, Power=%f, Company=%s", data.DataTime, data.Power, data.Company)
 	}
 
 	logx.Info("文件上传并处理成功")
+	return stored
 }
@@ -0,0 +1,305 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"power/internal/types"
+	"power/model"
+)
+
+const (
+	windowStepSlots       = slotsPerHour // 候选子时段的起止时刻取整点，时长不短于 1 小时
+	maxOptimizationPasses = 5            // 坐标下降的最大轮数
+	maxWindowCandidates   = 400          // 最多评估的候选时段组合数，达到后返回已找到的最优时段
+)
+
+// 一组充放电时段的测算和调度结果
+type windowEvaluation struct {
+	periods           []types.CapacityPeriod
+	cabinetCount      int
+	bindingConstraint string
+	value             arbitrageValue
+}
+
+// 日均峰谷套利收益 (元)
+func (e windowEvaluation) dailyValue() float64 {
+	if e.value.days == 0 {
+		return 0
+	}
+	return e.value.revenue() / float64(e.value.days)
+}
+
+func (e windowEvaluation) schedule() types.WindowSchedule {
+	schedule := types.WindowSchedule{
+		Periods:           e.periods,
+		CabinetCount:      e.cabinetCount,
+		BindingConstraint: e.bindingConstraint,
+		DailyValue:        e.dailyValue(),
+	}
+	if e.value.days > 0 {
+		schedule.DailyChargeEnergy = e.value.chargeEnergy / float64(e.value.days)
+		schedule.DailyDischargeEnergy = e.value.dischargeEnergy / float64(e.value.days)
+	}
+	return schedule
+}
+
+// 单个测算时段在某一天按电量和功率约束计算的储能柜台数，ok 为 false 表示当天该时段无有效数据
+type dayPeriodCabinets struct {
+	cabinets   float64
+	constraint string
+	ok         bool
+}
+
+// 时段的按日测算结果只取决于时刻范围、充放电类型和首尾标记，不同候选组合中相同的时段共用一份结果
+type periodKey struct {
+	window        clockWindow
+	charge        bool
+	firstCharge   bool
+	lastDischarge bool
+}
+
+// 充放电时段优化器：每组候选时段先按日测算储能柜最小台数，再按该台数和时段调度并按电价计价
+// 台数测算已计入变压器余量和负荷约束，调度时充电不超过变压器允许负荷，放电不超过当时负荷
+type windowOptimizer struct {
+	req       *types.CapacityConfigRequest
+	spec      cabinetSpec
+	schedules [12]tariffSchedule
+	days      map[string]*daySeries
+	points    []loadPoint // 开始日期起的负荷，用于调度模拟
+	startDate time.Time
+	endDate   time.Time
+	evaluated map[string]windowEvaluation
+	// 每个时段在日期范围内逐日的台数，按日测算只在时段第一次出现时进行
+	periodDays map[periodKey][]dayPeriodCabinets
+}
+
+// 创建充放电时段优化器，data 为包含开始日期前一天的功率数据，前一天的数据只用于跨零点时段的台数测算，调度从开始日期零点起模拟
+func newWindowOptimizer(req *types.CapacityConfigRequest, spec cabinetSpec, schedules [12]tariffSchedule,
+	data []model.PowerData, startDate, endDate time.Time) *windowOptimizer {
+	first := 0
+	for first < len(data) && data[first].DataTime.Before(startDate) {
+		first++
+	}
+	return &windowOptimizer{
+		req:        req,
+		spec:       spec,
+		schedules:  schedules,
+		days:       groupByDay(data),
+		points:     loadPoints(data[first:], req.MeterMultiplier),
+		startDate:  startDate,
+		endDate:    endDate,
+		evaluated:  make(map[string]windowEvaluation),
+		periodDays: make(map[periodKey][]dayPeriodCabinets),
+	}
+}
+
+// 按测算时段构造调度策略：测算时段的结束时刻包含在内，转换为左闭右开的调度时段，调度与测算使用相同的时刻
+func capacityStrategy(periods []capacityPeriod, windows []clockWindow) scheduleStrategy {
+	var strategy scheduleStrategy
+	for i, period := range periods {
+		window := clockWindow{startSlot: windows[i].startSlot, endSlot: (windows[i].endSlot + 1) % slotsPerDay}
+		if period.charge {
+			strategy.chargeWindows = append(strategy.chargeWindows, window)
+		} else {
+			strategy.dischargeWindows = append(strategy.dischargeWindows, window)
+		}
+	}
+	return strategy
+}
+
+// 测算并调度一组充放电时段，结果按时段缓存
+func (o *windowOptimizer) evaluate(periods []types.CapacityPeriod) (windowEvaluation, error) {
+	key := fmt.Sprint(periods)
+	if e, ok := o.evaluated[key]; ok {
+		return e, nil
+	}
+
+	capacity, err := capacityPeriods(periods)
+	if err != nil {
+		return windowEvaluation{}, err
+	}
+	windows, err := periodWindows(capacity)
+	if err != nil {
+		return windowEvaluation{}, err
+	}
+	strategy := capacityStrategy(capacity, windows)
+
+	e := windowEvaluation{periods: periods}
+	e.cabinetCount, e.bindingConstraint, err = o.minCabinetCount(capacity, windows)
+	if err != nil {
+		return windowEvaluation{}, err
+	}
+	if e.cabinetCount > 0 {
+		slots := simulateDispatch(o.spec.battery(e.cabinetCount), o.points, strategy, transformerLimit(o.req))
+		e.value = priceDispatch(slots, o.schedules)
+	}
+	o.evaluated[key] = e
+	return e, nil
+}
+
+// 与按日测算相同，储能柜台数取日期范围内每天台数的最小值，无有效数据时返回 0
+func (o *windowOptimizer) minCabinetCount(periods []capacityPeriod, windows []clockWindow) (int, string, error) {
+	days := make([][]dayPeriodCabinets, len(periods))
+	for i, period := range periods {
+		var err error
+		days[i], err = o.cabinetsByDay(period, windows[i])
+		if err != nil {
+			return 0, "", err
+		}
+	}
+
+	count, binding := -1, ""
+	for day := range days[0] {
+		// 与 evaluatePeriodLoads 相同：任一时段无有效数据时跳过当天，台数取各时段的最小值
+		limiting, ok := 0, true
+		for i := range periods {
+			if !days[i][day].ok {
+				ok = false
+				break
+			}
+			if days[i][day].cabinets < days[limiting][day].cabinets {
+				limiting = i
+			}
+		}
+		if !ok {
+			continue
+		}
+		if cabinetCount := int(days[limiting][day].cabinets); count < 0 || cabinetCount < count {
+			count = cabinetCount
+			binding = days[limiting][day].constraint
+		}
+	}
+	if count < 0 {
+		return 0, "", nil
+	}
+	return count, binding, nil
+}
+
+// 单个时段在日期范围内逐日的储能柜台数，结果按时段缓存
+func (o *windowOptimizer) cabinetsByDay(period capacityPeriod, window clockWindow) ([]dayPeriodCabinets, error) {
+	key := periodKey{window: window, charge: period.charge, firstCharge: period.firstCharge, lastDischarge: period.lastDischarge}
+	if days, ok := o.periodDays[key]; ok {
+		return days, nil
+	}
+
+	var days []dayPeriodCabinets
+	for date := o.startDate; !date.After(o.endDate); date = date.AddDate(0, 0, 1) {
+		load, err := windowLoad(window.data(o.days, date), window, o.req.CalculationMethod)
+		if err != nil {
+			return nil, err
+		}
+		var day dayPeriodCabinets
+		if results, _, _, ok := evaluatePeriodLoads(o.req, o.spec, []capacityPeriod{period}, []periodLoad{load}); ok {
+			day = dayPeriodCabinets{cabinets: results[0].cabinets, constraint: results[0].constraint, ok: true}
+		}
+		days = append(days, day)
+	}
+	o.periodDays[key] = days
+	return days, nil
+}
+
+// 坐标下降搜索：从完整的电价时段出发，依次把每个电价时段替换为其中的候选子时段或不使用，
+// 收益提高则保留，直到一轮内没有改进；始终保留至少一个充电和一个放电时段
+// 评估的组合数达到 maxWindowCandidates 时停止搜索，返回已找到的最优时段
+func (o *windowOptimizer) optimize(blocks []tariffBlock) (windowEvaluation, error) {
+	candidates := make([][][2]int, len(blocks))
+	for i, b := range blocks {
+		candidates[i] = blockCandidates(b)
+	}
+	choice := make([]int, len(blocks))
+	best, err := o.evaluate(blockPeriods(blocks, candidates, choice))
+	if err != nil {
+		return windowEvaluation{}, err
+	}
+
+	for pass := 0; pass < maxOptimizationPasses; pass++ {
+		improved := false
+		for i := range blocks {
+			// -1 表示不使用该电价时段
+			for c := -1; c < len(candidates[i]); c++ {
+				if c == choice[i] {
+					continue
+				}
+				trial := append([]int(nil), choice...)
+				trial[i] = c
+				periods := blockPeriods(blocks, candidates, trial)
+				if !hasChargeAndDischarge(periods) {
+					continue
+				}
+				if len(o.evaluated) >= maxWindowCandidates {
+					return best, nil
+				}
+				e, err := o.evaluate(periods)
+				if err != nil {
+					return windowEvaluation{}, err
+				}
+				if e.dailyValue() > best.dailyValue()+1e-6 {
+					best = e
+					choice = trial
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return best, nil
+}
+
+// 充放电时段按 month 的电价生成，日期范围内其他月份的充放电时段不同时无法用同一组时段调度，需按季节分别优化
+func checkUniformBlocks(schedules [12]tariffSchedule, month int, blocks []tariffBlock, startDate, endDate time.Time) error {
+	for date := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, startDate.Location()); !date.After(endDate); date = date.AddDate(0, 1, 0) {
+		other, err := schedules[date.Month()-1].blocks()
+		if err != nil || !slices.Equal(other, blocks) {
+			return fmt.Errorf("charge and discharge periods of month %d differ from month %d, optimise each season's date range separately", date.Month(), month)
+		}
+	}
+	return nil
+}
+
+// 电价时段内的候选子时段 [start, end)，起止时刻取整点和电价时段边界；第一个候选为整个电价时段
+func blockCandidates(b tariffBlock) [][2]int {
+	points := []int{b.start}
+	for slot := (b.start/windowStepSlots + 1) * windowStepSlots; slot < b.end; slot += windowStepSlots {
+		points = append(points, slot)
+	}
+	points = append(points, b.end)
+
+	candidates := [][2]int{{b.start, b.end}}
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			if points[i] == b.start && points[j] == b.end {
+				continue
+			}
+			if points[j]-points[i] >= windowStepSlots {
+				candidates = append(candidates, [2]int{points[i], points[j]})
+			}
+		}
+	}
+	return candidates
+}
+
+// 按各电价时段选中的候选子时段生成充放电时段，选择为 -1 的电价时段跳过
+func blockPeriods(blocks []tariffBlock, candidates [][][2]int, choice []int) []types.CapacityPeriod {
+	var periods []types.CapacityPeriod
+	for i, b := range blocks {
+		if choice[i] < 0 {
+			continue
+		}
+		window := candidates[i][choice[i]]
+		periods = append(periods, b.period(window[0], window[1]))
+	}
+	return periods
+}
+
+func hasChargeAndDischarge(periods []types.CapacityPeriod) bool {
+	var charge, discharge bool
+	for _, p := range periods {
+		charge = charge || p.Kind == periodCharge
+		discharge = discharge || p.Kind == periodDischarge
+	}
+	return charge && discharge
+}
@@ -0,0 +1,187 @@
+package logic
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"power/internal/types"
+	"power/model"
+)
+
+// 全年通用的分时电价：夜间低谷，上午和傍晚高峰
+var optimizerTariffPeriods = []types.TariffPeriod{
+	{Kind: tariffValley, Start: "00:00", End: "08:00", Price: 0.3},
+	{Kind: tariffPeak, Start: "08:00", End: "12:00", Price: 1.2},
+	{Kind: tariffFlat, Start: "12:00", End: "17:00", Price: 0.7},
+	{Kind: tariffPeak, Start: "17:00", End: "22:00", Price: 1.2},
+	{Kind: tariffFlat, Start: "22:00", End: "00:00", Price: 0.7},
+}
+
+// 按日期范围生成逐 15 分钟的负荷，包含开始日期的前一天，负荷随时刻和星期变化
+func optimizerTestData(startDate, endDate time.Time) []model.PowerData {
+	var data []model.PowerData
+	for t := startDate.AddDate(0, 0, -1); t.Before(endDate.AddDate(0, 0, 1)); t = t.Add(slotMinutes * time.Minute) {
+		slot := float64(slotOfDay(t))
+		power := 1500 + 800*math.Sin(2*math.Pi*(slot-32)/slotsPerDay) + 100*float64(t.YearDay()%7)
+		data = append(data, model.PowerData{DataTime: t, Power: power})
+	}
+	return data
+}
+
+func newTestOptimizer(t *testing.T, startDate, endDate time.Time) (*windowOptimizer, [12]tariffSchedule) {
+	t.Helper()
+	schedules, err := tariffSchedules(optimizerTariffPeriods)
+	if err != nil {
+		t.Fatalf("tariffSchedules failed: %v", err)
+	}
+	req := &types.CapacityConfigRequest{
+		TransformerCapacity: 5000,
+		PowerFactor:         0.9,
+		MeterMultiplier:     1,
+		CalculationMethod:   "average",
+	}
+	spec, err := newCabinetSpec(req, &model.StorageProduct{
+		EnergyCapacity: 261, PcsPower: 125, RoundTripEfficiency: 0.92, PcsEfficiency: 0.97, DepthOfDischarge: 0.9, AuxiliaryPower: 1.8,
+	})
+	if err != nil {
+		t.Fatalf("newCabinetSpec failed: %v", err)
+	}
+	return newWindowOptimizer(req, spec, schedules, optimizerTestData(startDate, endDate), startDate, endDate), schedules
+}
+
+// 逐日重新测算全部时段的台数，作为按时段缓存结果的对照
+func bruteForceCabinetCount(t *testing.T, o *windowOptimizer, periods []types.CapacityPeriod) int {
+	t.Helper()
+	capacity, err := capacityPeriods(periods)
+	if err != nil {
+		t.Fatal(err)
+	}
+	windows, err := periodWindows(capacity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := -1
+	for date := o.startDate; !date.After(o.endDate); date = date.AddDate(0, 0, 1) {
+		loads := make([]periodLoad, len(windows))
+		for i, window := range windows {
+			if loads[i], err = windowLoad(window.data(o.days, date), window, o.req.CalculationMethod); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, cabinetCount, _, ok := evaluatePeriodLoads(o.req, o.spec, capacity, loads); ok && (count < 0 || cabinetCount < count) {
+			count = cabinetCount
+		}
+	}
+	return max(count, 0)
+}
+
+func TestWindowOptimizerMultiMonth(t *testing.T) {
+	startDate := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(2023, 6, 30, 0, 0, 0, 0, time.Local)
+	o, schedules := newTestOptimizer(t, startDate, endDate)
+
+	blocks, err := schedules[0].blocks()
+	if err != nil {
+		t.Fatalf("blocks failed: %v", err)
+	}
+	if err := checkUniformBlocks(schedules, 1, blocks, startDate, endDate); err != nil {
+		t.Fatalf("checkUniformBlocks failed: %v", err)
+	}
+	defaultPeriods, err := schedules[0].capacityPeriods()
+	if err != nil {
+		t.Fatalf("capacityPeriods failed: %v", err)
+	}
+	tariffDefault, err := o.evaluate(defaultPeriods)
+	if err != nil {
+		t.Fatalf("evaluate failed: %v", err)
+	}
+	optimal, err := o.optimize(blocks)
+	if err != nil {
+		t.Fatalf("optimize failed: %v", err)
+	}
+
+	if days := int(endDate.Sub(startDate).Hours()/24) + 1; optimal.value.days != days {
+		t.Errorf("optimal schedule simulated %d days, want %d", optimal.value.days, days)
+	}
+	if optimal.dailyValue() < tariffDefault.dailyValue() {
+		t.Errorf("optimal daily value %v is below the tariff default %v", optimal.dailyValue(), tariffDefault.dailyValue())
+	}
+	if len(o.evaluated) > maxWindowCandidates {
+		t.Errorf("evaluated %d candidates, want at most %d", len(o.evaluated), maxWindowCandidates)
+	}
+	for _, e := range o.evaluated {
+		if want := bruteForceCabinetCount(t, o, e.periods); e.cabinetCount != want {
+			t.Errorf("cabinet count for %v = %d, want %d", e.periods, e.cabinetCount, want)
+		}
+	}
+}
+
+func TestCheckUniformBlocks(t *testing.T) {
+	// 7-8 月傍晚高峰提前到 16:00
+	periods := append([]types.TariffPeriod(nil), optimizerTariffPeriods...)
+	for i := range periods {
+		periods[i].Months = []int{1, 2, 3, 4, 5, 6, 9, 10, 11, 12}
+	}
+	periods = append(periods,
+		types.TariffPeriod{Kind: tariffValley, Start: "00:00", End: "08:00", Price: 0.3, Months: []int{7, 8}},
+		types.TariffPeriod{Kind: tariffPeak, Start: "08:00", End: "12:00", Price: 1.2, Months: []int{7, 8}},
+		types.TariffPeriod{Kind: tariffFlat, Start: "12:00", End: "16:00", Price: 0.7, Months: []int{7, 8}},
+		types.TariffPeriod{Kind: tariffPeak, Start: "16:00", End: "22:00", Price: 1.2, Months: []int{7, 8}},
+		types.TariffPeriod{Kind: tariffFlat, Start: "22:00", End: "00:00", Price: 0.7, Months: []int{7, 8}},
+	)
+	schedules, err := tariffSchedules(periods)
+	if err != nil {
+		t.Fatalf("tariffSchedules failed: %v", err)
+	}
+	blocks, err := schedules[4].blocks()
+	if err != nil {
+		t.Fatalf("blocks failed: %v", err)
+	}
+
+	tests := []struct {
+		start, end time.Time
+		wantErr    bool
+	}{
+		{time.Date(2023, 4, 15, 0, 0, 0, 0, time.Local), time.Date(2023, 6, 30, 0, 0, 0, 0, time.Local), false},
+		{time.Date(2023, 5, 1, 0, 0, 0, 0, time.Local), time.Date(2023, 7, 1, 0, 0, 0, 0, time.Local), true},
+		{time.Date(2023, 9, 1, 0, 0, 0, 0, time.Local), time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local), false},
+	}
+	for _, tt := range tests {
+		err := checkUniformBlocks(schedules, 5, blocks, tt.start, tt.end)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkUniformBlocks(%s, %s) error = %v, wantErr %v", tt.start.Format(dateLayout), tt.end.Format(dateLayout), err, tt.wantErr)
+		}
+	}
+}
+
+func TestCapacityStrategyMatchesSizingSlots(t *testing.T) {
+	periods, err := capacityPeriods([]types.CapacityPeriod{
+		{Kind: periodCharge, Start: "22:00", End: "07:45"},
+		{Kind: periodDischarge, Start: "08:00", End: "11:45"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	windows, err := periodWindows(periods)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategy := capacityStrategy(periods, windows)
+	battery := batteryModel{power: 100}
+
+	// 调度的时刻与测算时段包含的时刻一一对应
+	for i, window := range windows {
+		dispatched := 0
+		for slot := 0; slot < slotsPerDay; slot++ {
+			point := loadPoint{time: time.Date(2023, 5, 1, 0, 0, 0, 0, time.Local).Add(time.Duration(slot) * slotMinutes * time.Minute)}
+			power := strategy.desiredPower(point, 0.5, battery)
+			if (periods[i].charge && power > 0) || (!periods[i].charge && power < 0) {
+				dispatched++
+			}
+		}
+		if dispatched != window.slots() {
+			t.Errorf("period %s-%s dispatched in %d slots, sized for %d", periods[i].start, periods[i].end, dispatched, window.slots())
+		}
+	}
+}
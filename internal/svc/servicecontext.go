@@ -8,14 +8,24 @@ import (
 )
 
 type ServiceContext struct {
-	Config config.Config
-	Model  model.PowerDataModel
+	Config            config.Config
+	Model             model.PowerDataModel
+	UploadLogModel    model.UploadLogModel
+	ProductModel      model.StorageProductModel
+	TariffModel       model.TariffModel
+	TariffPeriodModel model.TariffPeriodModel
+	ScenarioModel     model.ScenarioModel
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	conn := sqlx.NewMysql(c.Mysql.DataSource) // 修改为使用 c.Mysql.DataSource
 	return &ServiceContext{
-		Config: c,
-		Model:  model.NewPowerDataModel(conn),
+		Config:            c,
+		Model:             model.NewPowerDataModel(conn),
+		UploadLogModel:    model.NewUploadLogModel(conn),
+		ProductModel:      model.NewStorageProductModel(conn),
+		TariffModel:       model.NewTariffModel(conn),
+		TariffPeriodModel: model.NewTariffPeriodModel(conn),
+		ScenarioModel:     model.NewScenarioModel(conn),
 	}
 }
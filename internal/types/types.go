@@ -1,26 +1,432 @@
 // Code generated by goctl. DO NOT EDIT.
 package types
 
+type BasicChargeAdviceResponse struct {
+	TransformerCapacity     float64            // 变压器容量 (kVA)
+	DemandPrice             float64            // 需量电价 (元/kW·月)
+	CapacityPrice           float64            // 容量电价 (元/kVA·月)
+	MinimumDemand           float64            // 按需量计收的最低计费需量 (kW) = 变压器容量的 40%
+	HasStorage              bool               // 是否配置了储能
+	Months                  []BasicChargeMonth // 每月对比
+	CapacityTotal           float64            // 按容量计收的基本电费合计 (元)
+	DemandTotal             float64            // 无储能时按需量计收的基本电费合计 (元)
+	StorageDemandTotal      float64            // 储能调度后按需量计收的基本电费合计 (元)
+	Recommendation          string             // 无储能时整个期间合计较便宜的计收方式
+	StorageRecommendation   string             // 储能调度后整个期间合计较便宜的计收方式
+	BestMonthlyTotal        float64            // 无储能时每月都选择较便宜方式的基本电费合计 (元)
+	StorageBestMonthlyTotal float64            // 储能调度后每月都选择较便宜方式的基本电费合计 (元)，未配置储能时为 0
+}
+
+type BasicChargeMonth struct {
+	Month                 string  // 月份 (YYYY-MM)
+	MaxDemand             float64 // 无储能时的最大需量 (kW)
+	BilledDemand          float64 // 无储能时按需量计收的计费需量 (kW)，不低于 minimumDemand
+	DemandCharge          float64 // 无储能时按需量计收的基本电费 (元)
+	StorageMaxDemand      float64 // 储能调度后的最大需量 (kW)
+	StorageBilledDemand   float64 // 储能调度后的计费需量 (kW)
+	StorageDemandCharge   float64 // 储能调度后按需量计收的基本电费 (元)
+	CapacityCharge        float64 // 按容量计收的基本电费 (元)，与需量和储能无关
+	Recommendation        string  // 无储能时较便宜的计收方式：capacity 或 demand
+	Savings               float64 // 无储能时较便宜的方式比另一种方式节省的基本电费 (元)
+	StorageRecommendation string  // 储能调度后较便宜的计收方式，未配置储能时为空
+	StorageSavings        float64 // 储能调度后较便宜的方式比另一种方式节省的基本电费 (元)
+}
+
+type BatteryConfig struct {
+	EnergyCapacity      float64 `json:"energyCapacity"`                // 储能系统额定能量 (kWh)
+	PowerRating         float64 `json:"powerRating"`                   // 储能系统最大充放电功率 (kW)
+	MinSoc              float64 `json:"minSoc,default=0"`              // 最低荷电状态 (0-1)
+	MaxSoc              float64 `json:"maxSoc,default=1"`              // 最高荷电状态 (0-1)
+	InitialSoc          float64 `json:"initialSoc,optional"`           // 初始荷电状态 (0-1)，不超过 maxSoc，低于 minSoc 时取 minSoc
+	RoundTripEfficiency float64 `json:"roundTripEfficiency,default=1"` // 往返效率 (0-1)
+	AuxiliaryPower      float64 `json:"auxiliaryPower,optional"`       // 储能系统辅助用电功率 (kW)，充电或放电时计入电网负荷
+}
+
+type BillComparison struct {
+	Month       string      // 月份 (YYYY-MM)
+	Baseline    MonthlyBill // 无储能时的电费
+	WithStorage MonthlyBill // 储能调度后的电费，未配置储能时为空
+	Savings     float64     // 节省的电费 (元)
+}
+
+type BillItem struct {
+	Kind   string  // 电价时段：critical 尖峰，peak 高峰，flat 平段，valley 低谷
+	Energy float64 // 用电量 (kWh)
+	Charge float64 // 电度电费 (元)
+}
+
+type BillingExportRequest struct {
+	Billing  BillingRequest        `json:"billing"`                              // 电费测算参数
+	Capacity CapacityConfigRequest `json:"capacity,optional"`                    // 容量测算参数，填写时导出文件附带容量测算结果
+	Format   string                `json:"format,default=xlsx,options=csv|xlsx"` // 文件格式，csv 只包含电费对比
+}
+
+type BillingRequest struct {
+	Company             string           `json:"company"`                                                      // 公司名称
+	StartTime           string           `json:"startTime"`                                                    // 负荷数据开始时间，格式：YYYY-MM-DD HH:MM:SS，按自然月出具电费
+	EndTime             string           `json:"endTime"`                                                      // 负荷数据结束时间，格式：YYYY-MM-DD HH:MM:SS
+	MeterMultiplier     float64          `json:"meterMultiplier,default=1"`                                    // 电表倍率
+	PowerFactor         float64          `json:"powerFactor,default=1"`                                        // 功率因数
+	TransformerCapacity float64          `json:"transformerCapacity,optional"`                                 // 变压器容量 (kVA)，按容量计收基本电费时必填，按需量计收时计费需量不低于其 40%；储能充电时负荷不超过变压器容量 * 功率因数
+	TariffId            int64            `json:"tariffId"`                                                     // 分时电价 id，提供电度电价、需量电价和容量电价
+	BasicChargeMode     string           `json:"basicChargeMode,default=demand,options=demand|capacity"`       // 基本电费计收方式：demand 按最大需量，capacity 按变压器容量
+	ProductId           int64            `json:"productId,optional"`                                           // 储能柜产品 id，与 cabinetCount 一起确定储能系统；产品和 battery 均未填写时只出具基线电费
+	CabinetCount        int              `json:"cabinetCount,optional"`                                        // 储能柜台数
+	Cabinet             CabinetParams    `json:"cabinet,optional"`                                             // 指定产品时覆盖产品参数的储能柜参数，与 /capacity/ 的同名参数相同
+	Battery             BatteryConfig    `json:"battery,optional"`                                             // 储能系统参数，未指定产品时使用
+	Strategy            string           `json:"strategy,default=tariff,options=tariff|schedule|peak_shaving"` // 调度策略：tariff 按分时电价，schedule 按 periods，peak_shaving 按 targetDemand 削峰
+	Periods             []CapacityPeriod `json:"periods,optional"`                                             // strategy 为 schedule 时的充放电时段，使用当天时刻 (HH:MM)
+	TargetDemand        float64          `json:"targetDemand,optional"`                                        // strategy 为 peak_shaving 时的目标需量 (kW)
+}
+
+type BillingResponse struct {
+	BasicChargeMode string           // 基本电费计收方式
+	HasStorage      bool             // 是否配置了储能
+	Months          []BillComparison // 每月电费对比
+	BaselineTotal   float64          // 基线电费合计 (元)
+	StorageTotal    float64          // 储能调度后的电费合计 (元)
+	TotalSavings    float64          // 节省的电费合计 (元)
+}
+
+type CabinetParams struct {
+	DischargeCapacity   float64 `json:"dischargeCapacity,optional"`   // 储能柜实际放电容量 (kWh)，为 0 时取产品的额定能量
+	RoundTripEfficiency float64 `json:"roundTripEfficiency,optional"` // 电池往返效率 (0-1]，为 0 时取产品参数
+	PcsEfficiency       float64 `json:"pcsEfficiency,optional"`       // PCS 单向转换效率 (0-1]，为 0 时取产品参数
+	DepthOfDischarge    float64 `json:"depthOfDischarge,optional"`    // 可用放电深度 (0-1]，为 0 时取产品参数
+	InitialSoc          float64 `json:"initialSoc,optional"`          // 开始调度时的荷电状态 (0-1)，低于 1-DoD 时取 1-DoD
+	AuxiliaryPower      float64 `json:"auxiliaryPower,optional"`      // 单台储能柜辅助用电功率 (kW)，为 0 时取产品参数
+	PcsPower            float64 `json:"pcsPower,optional"`            // 单台储能柜 PCS 额定功率 (kW)，为 0 时取产品参数
+}
+
+type CabinetSummary struct {
+	Min    float64 // 每日储能柜台数的最小值
+	P10    float64 // 10 百分位数
+	Median float64 // 中位数
+	P90    float64 // 90 百分位数
+	Max    float64 // 最大值
+}
+
 type CapacityConfigRequest struct {
-	Company               string   `json:"company"`
-	PowerFactor           float64  `json:"powerFactor"`           // 功率因数
-	TransformerCapacity   float64  `json:"transformerCapacity"`   // 变压器容量 (kW)
-	MeterMultiplier       float64  `json:"meterMultiplier"`       // 电表倍率
-	DischargeCapacity     float64  `json:"dischargeCapacity"`     // 储能柜实际放电容量 (kWh)
-	ChargeCapacity        float64  `json:"chargeCapacity"`        // 储能柜实际充电容量 (kWh)
-	FirstChargePeriod     []string `json:"firstChargePeriod"`     // 第一次充电时段，例如 [9, 11] 表示9-11点
-	FirstDischargePeriod  []string `json:"firstDischargePeriod"`  // 第一次放电时段
-	SecondChargePeriod    []string `json:"secondChargePeriod"`    // 第二次充电时段
-	SecondDischargePeriod []string `json:"secondDischargePeriod"` // 第二次放电时段
-	CalculationMethod     string   `json:"calculationMethod"`     //计算方法：平均数、中位数、众数、百分位数等
+	Company              string           `json:"company"`
+	PowerFactor          float64          `json:"powerFactor"`                   // 功率因数
+	TransformerCapacity  float64          `json:"transformerCapacity"`           // 变压器容量 (kW)
+	MeterMultiplier      float64          `json:"meterMultiplier"`               // 电表倍率
+	DischargeCapacity    float64          `json:"dischargeCapacity,optional"`    // 储能柜实际放电容量 (kWh)，为 0 时取产品的额定能量
+	ChargeCapacity       float64          `json:"chargeCapacity,optional"`       // 储能柜实际充电容量 (kWh)，为 0 时取产品的额定能量
+	ProductId            int64            `json:"productId,optional"`            // 储能柜产品 id，指定后未填写的容量、效率、放电深度和辅助用电取产品参数
+	Periods              []CapacityPeriod `json:"periods,optional"`              // 按时间顺序排列的充放电时段，数量不限；为空时按 tariffId 指定的分时电价生成
+	CalculationMethod    string           `json:"calculationMethod"`             //计算方法：平均数、中位数、众数、四分位数等，支持 percentile:85、mean+1.5*stddev 等写法，可用方法见 GET /methods
+	StartDate            string           `json:"startDate,optional"`            // 按日测算的开始日期，格式：YYYY-MM-DD
+	EndDate              string           `json:"endDate,optional"`              // 按日测算的结束日期，格式：YYYY-MM-DD
+	RoundTripEfficiency  float64          `json:"roundTripEfficiency,optional"`  // 电池往返效率 (0-1]，为 0 时取产品参数或 1
+	PcsEfficiency        float64          `json:"pcsEfficiency,optional"`        // PCS 单向转换效率 (0-1]，为 0 时取产品参数或 1
+	DepthOfDischarge     float64          `json:"depthOfDischarge,optional"`     // 可用放电深度 (0-1]，为 0 时取产品参数或 1，荷电状态在 [1-DoD, 1] 之间运行
+	InitialSoc           float64          `json:"initialSoc,optional"`           // 首个充电时段开始时的荷电状态 (0-1)，低于 1-DoD 时取 1-DoD
+	EndSoc               float64          `json:"endSoc,optional"`               // 最后一个放电时段结束时的荷电状态 (0-1)，低于 1-DoD 时取 1-DoD
+	AuxiliaryPower       float64          `json:"auxiliaryPower,optional"`       // 单台储能柜辅助用电功率 (kW)，空调、BMS 等，为 0 时取产品参数
+	PcsPower             float64          `json:"pcsPower,optional"`             // 单台储能柜 PCS 额定功率 (kW)，为 0 时取产品参数；无 PCS 功率时只按电量计算台数
+	AntiBackflow         bool             `json:"antiBackflow,optional"`         // 防逆流模式：放电时段每个时刻的放电功率不超过当时负荷减去安全裕度
+	BackflowMargin       float64          `json:"backflowMargin,optional"`       // 防逆流安全裕度 (kW)
+	OverloadFactor       float64          `json:"overloadFactor,default=1"`      // 变压器短时过载系数，例如 1.1 表示充电时每个时刻允许达到额定容量的 110%
+	ProposedCabinetCount int              `json:"proposedCabinetCount,optional"` // 校验变压器过载的储能柜台数，为 0 时取测算出的最小台数
+	Sensitivity          bool             `json:"sensitivity,optional"`          // 敏感性分析：在同一份数据上按所有计算方法分别测算并返回对比表
+	Percentiles          []float64        `json:"percentiles,optional"`          // 敏感性分析额外比较的百分位数 (0-100)，例如 [80, 95]
+	TariffId             int64            `json:"tariffId,optional"`             // 分时电价 id，未填写 periods 和 seasons 时按电价生成充放电时段：低谷充电，高峰和尖峰放电，平段不动作，结束时刻取电价时段内最后一个 15 分钟时刻（例如低谷 00:00-08:00 生成 00:00-07:45），仅支持按日测算
+	TariffMonth          int              `json:"tariffMonth,optional"`          // 生成充放电时段使用的月份 (1-12)，指定时所有日期使用该月的时段；为 0 时按电价生成日期范围内各月份的分季时段，每天使用所在月份的时段；此前为 0 时所有日期都使用开始日期所在月份的时段
+	Seasons              []CapacitySeason `json:"seasons,optional"`              // 分季充放电时段，每天按所在月份使用对应季节的时段，仅支持按日测算，与 periods 二选一
+	ScenarioName         string           `json:"scenarioName,optional"`         // 保存测算方案使用的名称，为空时按公司和日期生成
+	Author               string           `json:"author,optional"`               // 测算方案的创建人
 }
 
 type CapacityConfigResponse struct {
-	MinCabinetCount       int     // 储能柜最小台数
-	FirstChargeAmount     float64 // 第一次充电量 (kWh)
-	FirstDischargeAmount  float64 // 第一次放电量 (kWh)
-	SecondChargeAmount    float64 // 第二次充电量 (kWh)
-	SecondDischargeAmount float64 // 第二次放电量 (kWh)
+	MinCabinetCount      int              // 储能柜最小台数
+	Periods              []PeriodResult   // 各时段的测算结果，与请求中的时段一一对应
+	LimitingPeriod       int              // 决定储能柜台数的时段序号（从 0 开始），即台数最少的时段
+	BindingConstraint    string           // 决定储能柜台数的约束，即 limitingPeriod 时段的 constraint
+	BackflowLoss         float64          // 防逆流模式下各放电时段少放的电量之和 (kWh)，按日测算时为所有日期之和
+	ProposedCabinetCount int              // 校验变压器过载使用的储能柜台数
+	OverloadSlots        []OverloadSlot   // 按 proposedCabinetCount 台储能柜充电时变压器超出允许负荷的时刻
+	Sensitivity          []SensitivityRow // 敏感性分析对比表，仅 sensitivity 为 true 时返回
+	Days                 []DailyCapacity  // 按日测算结果，仅指定 startDate/endDate 时返回
+	CabinetSummary       CabinetSummary   // 每日储能柜台数的分布
+	ConstrainingDay      string           // 决定储能柜台数的日期，即台数最少的一天，上面的时段结果取自该日
+	SkippedDays          []string         // 因时段内无数据或不属于任何季节而未参与测算的日期
+	Seasons              []SeasonCapacity // 分季测算结果，仅按 seasons 或按电价分季测算时返回
+	ScenarioId           int64            // 保存的测算方案 id，保存失败时为 0
+}
+
+type CapacityPeriod struct {
+	Kind  string `json:"kind,options=charge|discharge"` // 时段类型：charge 充电，discharge 放电
+	Start string `json:"start"`                         // 开始时间，完整日期时间，或指定 startDate/endDate 时为时刻，例如 "08:00"
+	End   string `json:"end"`                           // 结束时间，格式与 start 相同；早于开始时间表示跨零点，例如 22:00 至次日 08:00，按日测算时电量计入结束所在的日期
+}
+
+type CapacitySeason struct {
+	Name    string           `json:"name,optional"` // 季节名称，例如 summer，为空时取月份列表
+	Months  []int            `json:"months"`        // 适用月份 (1-12)，每个月份只能属于一个季节，不属于任何季节的日期不参与测算
+	Periods []CapacityPeriod `json:"periods"`       // 该季节按时间顺序排列的充放电时段，使用当天时刻 (HH:MM)
+}
+
+type CashFlowYear struct {
+	Year               int     // 第几年
+	Revenue            float64 // 峰谷套利收益 (元)，已计入衰减
+	OmCost             float64 // 运维费用 (元)
+	NetCashFlow        float64 // 净现金流 (元)
+	CumulativeCashFlow float64 // 含初始投资的累计现金流 (元)
+	PresentValue       float64 // 净现金流的现值 (元)
+}
+
+type CompanyInfo struct {
+	Company        string // 公司名称
+	FirstTime      string // 最早数据时间
+	LastTime       string // 最晚数据时间
+	RowCount       int64  // 数据条数
+	CompleteDays   int    // 96 个时刻齐全的天数
+	MissingDays    int    // 首尾日期之间数据不完整或缺失的天数
+	LastUploadTime string // 最近一次上传时间，无上传记录时为空
+	LastUploadFile string // 最近一次上传的文件名
+}
+
+type CompanyListResponse struct {
+	Companies []CompanyInfo
+}
+
+type CoverageDay struct {
+	Date      string // 日期 (YYYY-MM-DD)
+	SlotCount int    // 当天不重复的数据时刻数量
+	Complete  bool   // 当天 96 个时刻是否齐全
+}
+
+type CoverageRequest struct {
+	Company string `form:"company"` // 公司名称
+}
+
+type CoverageResponse struct {
+	Company string
+	Days    []CoverageDay // 首尾日期之间的每一天，无数据的日期 slotCount 为 0
+}
+
+type CurvePoint struct {
+	Time  string  // 时刻，例如 08:15
+	Value float64 // 该时刻的统计功率
+	Min   float64 // 该时刻的最小功率
+	Max   float64 // 该时刻的最大功率
+	Count int     // 参与统计的天数
+}
+
+type DailyCapacity struct {
+	Date              string         // 日期 (YYYY-MM-DD)
+	MinCabinetCount   int            // 当天的储能柜最小台数
+	Periods           []PeriodResult // 当天各时段的测算结果
+	LimitingPeriod    int            // 决定当天台数的时段序号
+	BindingConstraint string         // 决定当天台数的约束
+}
+
+type DailyThroughput struct {
+	Date            string  // 日期 (YYYY-MM-DD)
+	ChargeEnergy    float64 // 当天充电量 (kWh)
+	DischargeEnergy float64 // 当天放电量 (kWh)
+	Cycles          float64 // 当天等效循环次数 = 放电量 / 可用能量
+}
+
+type DemandMonth struct {
+	Month        string  // 月份 (YYYY-MM)
+	Days         int     // 该月有负荷数据的天数
+	PeakDemand   float64 // 原始最大需量 (kW)
+	PeakTime     string  // 原始最大需量出现的时刻
+	TargetDemand float64 // 该月的目标需量 (kW)
+	ShavedDemand float64 // 削峰后的最大需量 (kW)
+	ShavedTime   string  // 削峰后最大需量出现的时刻
+	Reduction    float64 // 需量降低值 (kW)
+	BaseCharge   float64 // 原始基本电费 (元)，与 /billing/comparison 相同按计费需量计算，计费需量不低于变压器容量的 40%
+	ShavedCharge float64 // 削峰后的基本电费 (元)
+	Savings      float64 // 节省的基本电费 (元)
+}
+
+type DemandRequest struct {
+	Company             string        `json:"company"`                      // 公司名称
+	StartTime           string        `json:"startTime"`                    // 负荷数据开始时间，格式：YYYY-MM-DD HH:MM:SS，按自然月统计最大需量
+	EndTime             string        `json:"endTime"`                      // 负荷数据结束时间，格式：YYYY-MM-DD HH:MM:SS
+	MeterMultiplier     float64       `json:"meterMultiplier,default=1"`    // 电表倍率
+	PowerFactor         float64       `json:"powerFactor,default=1"`        // 功率因数
+	TransformerCapacity float64       `json:"transformerCapacity,optional"` // 变压器容量 (kW)，充电时负荷不超过变压器容量 * 功率因数，为 0 时不限制；计费需量不低于变压器容量的 40%
+	TariffId            int64         `json:"tariffId,optional"`            // 分时电价 id，取其需量电价
+	DemandPrice         float64       `json:"demandPrice,optional"`         // 需量电价 (元/kW·月)，为 0 时取电价中的需量电价
+	ProductId           int64         `json:"productId"`                    // 储能柜产品 id
+	Cabinet             CabinetParams `json:"cabinet,optional"`             // 覆盖产品参数的储能柜参数，与 /capacity/ 的同名参数相同；削峰时每月从满电开始调度，忽略 initialSoc
+	TargetDemand        float64       `json:"targetDemand,optional"`        // 目标需量 (kW)：测算把每月最大需量削到该值以下所需的最少台数
+	CabinetCount        int           `json:"cabinetCount,optional"`        // 储能柜台数：测算该台数下每月能守住的最低需量，与 targetDemand 二选一
+	ScenarioName        string        `json:"scenarioName,optional"`        // 保存测算方案使用的名称，为空时按公司和日期生成
+	Author              string        `json:"author,optional"`              // 测算方案的创建人
+}
+
+type DemandResponse struct {
+	DemandPrice   float64       // 需量电价 (元/kW·月)
+	CabinetCount  int           // 按目标需量测算的最少台数，或请求中的台数
+	TargetDemand  float64       // 请求中的目标需量 (kW)，按台数测算时为 0
+	Months        []DemandMonth // 每月的需量和基本电费
+	TotalSavings  float64       // 数据覆盖月份节省的基本电费合计 (元)
+	AnnualSavings float64       // 按覆盖的天数折算为全年的节省 (元)
+	ScenarioId    int64         // 保存的测算方案 id，保存失败时为 0
+}
+
+type DispatchSlot struct {
+	Time           string  // 数据时间
+	Load           float64 // 场站负荷 (kW)
+	ChargePower    float64 // 充电功率 (kW)
+	DischargePower float64 // 放电功率 (kW)
+	Soc            float64 // 时刻结束时的荷电状态 (0-1)
+	NetLoad        float64 // 储能动作后的电网负荷 (kW)
+}
+
+type DurationPoint struct {
+	Percent float64 // 时间占比 (%)
+	Hours   float64 // 累计小时数
+	Power   float64 // 功率 (kW)
+}
+
+type EconomicsRequest struct {
+	Company             string        `json:"company"`                      // 公司名称
+	StartTime           string        `json:"startTime"`                    // 用于模拟调度的负荷数据开始时间，格式：YYYY-MM-DD HH:MM:SS，收益按覆盖天数折算为全年
+	EndTime             string        `json:"endTime"`                      // 负荷数据结束时间，格式：YYYY-MM-DD HH:MM:SS
+	MeterMultiplier     float64       `json:"meterMultiplier,default=1"`    // 电表倍率
+	PowerFactor         float64       `json:"powerFactor,default=1"`        // 功率因数
+	TransformerCapacity float64       `json:"transformerCapacity,optional"` // 变压器容量 (kW)，充电时负荷不超过变压器容量 * 功率因数，为 0 时不限制
+	TariffId            int64         `json:"tariffId"`                     // 分时电价 id，低谷充电、高峰和尖峰放电，并按电价计算收益
+	ProductId           int64         `json:"productId,optional"`           // 储能柜产品 id，与 cabinetCount 一起确定储能系统，指定后忽略 battery
+	CabinetCount        int           `json:"cabinetCount,optional"`        // 储能柜台数，例如 /capacity/ 测算出的最小台数
+	Cabinet             CabinetParams `json:"cabinet,optional"`             // 指定产品时覆盖产品参数的储能柜参数，与 /capacity/ 的同名参数相同
+	Battery             BatteryConfig `json:"battery,optional"`             // 储能系统参数，未指定产品时使用
+	CapitalCost         float64       `json:"capitalCost,optional"`         // 初始投资 (元)，为 0 时取产品单价 × 台数
+	AnnualOmCost        float64       `json:"annualOmCost,optional"`        // 年运维费用 (元)
+	LifeYears           int           `json:"lifeYears,default=10"`         // 运行年限
+	DiscountRate        float64       `json:"discountRate,default=0.08"`    // 折现率，需大于 -1
+	DegradationRate     float64       `json:"degradationRate,default=0.02"` // 年衰减率，第 n 年收益为首年的 (1-衰减率)^(n-1)
+}
+
+type EconomicsResponse struct {
+	SimulatedDays         int            // 参与模拟的天数
+	BatteryEnergy         float64        // 储能系统额定能量 (kWh)
+	BatteryPower          float64        // 储能系统最大充放电功率 (kW)
+	AnnualChargeEnergy    float64        // 年充电量 (kWh)
+	AnnualDischargeEnergy float64        // 年放电量 (kWh)
+	AnnualChargeCost      float64        // 年充电电费 (元)
+	AnnualDischargeValue  float64        // 年放电节省的电费 (元)
+	AnnualRevenue         float64        // 首年峰谷套利收益 (元) = 放电节省的电费 - 充电电费
+	AnnualOmCost          float64        // 年运维费用 (元)
+	CapitalCost           float64        // 初始投资 (元)
+	SimplePayback         float64        // 静态投资回收期 (年) = 初始投资 / 首年净现金流，无法回本时为 0
+	Npv                   float64        // 净现值 (元)
+	Irr                   float64        // 内部收益率
+	IrrAvailable          bool           // 是否存在内部收益率
+	CashFlows             []CashFlowYear // 逐年现金流
+}
+
+type ExportRequest struct {
+	Company   string `form:"company"`                                // 公司名称
+	StartTime string `form:"startTime"`                              // 开始时间，格式：YYYY-MM-DD HH:MM:SS
+	EndTime   string `form:"endTime"`                                // 结束时间，格式：YYYY-MM-DD HH:MM:SS
+	Format    string `form:"format,default=csv,options=csv|xlsx"`    // 文件格式
+	Layout    string `form:"layout,default=long,options=long|wide"`  // long 每行一个时刻；wide 每行一天 96 列，与"数据日期"格式一致
+	Interval  string `form:"interval,default=15m,options=15m|1h|1d"` // 聚合粒度，15m 为原始数据，wide 布局仅支持 15m
+	Method    string `form:"method,default=average"`                 // 聚合时使用的统计方法，与容量计算的 calculationMethod 相同
+}
+
+type HeatmapRequest struct {
+	Company   string `form:"company"`   // 公司名称
+	StartTime string `form:"startTime"` // 开始时间，格式：YYYY-MM-DD HH:MM:SS
+	EndTime   string `form:"endTime"`   // 结束时间，格式：YYYY-MM-DD HH:MM:SS
+}
+
+type HeatmapResponse struct {
+	Slots    []string     // 96 个时刻标签，与 values 一一对应
+	Rows     []HeatmapRow // 按日期升序排列，无数据的日期也会列出
+	MinPower float64      // 全部数据的最小功率，用于色阶
+	MaxPower float64      // 全部数据的最大功率，用于色阶
+}
+
+type HeatmapRow struct {
+	Date     string    // 日期 (YYYY-MM-DD)
+	Values   []float64 // 96 个时刻的功率，缺失时为 0
+	Missing  []int     // 缺失数据的时刻序号 (0-95)
+	Complete bool      // 当天 96 个时刻是否齐全
+}
+
+type LoadDurationRequest struct {
+	Company             string    `json:"company"`                      // 公司名称
+	StartTime           string    `json:"startTime"`                    // 开始时间，格式：YYYY-MM-DD HH:MM:SS
+	EndTime             string    `json:"endTime"`                      // 结束时间，格式：YYYY-MM-DD HH:MM:SS
+	Points              int       `json:"points,default=100"`           // 持续曲线降采样后的点数
+	Thresholds          []float64 `json:"thresholds,optional"`          // 功率阈值 (kW)，统计高于各阈值的小时数
+	MeterMultiplier     float64   `json:"meterMultiplier,default=1"`    // 电表倍率
+	PowerFactor         float64   `json:"powerFactor,default=1"`        // 功率因数
+	TransformerCapacity float64   `json:"transformerCapacity,optional"` // 变压器容量 (kW)，为 0 时不计算负载率
+}
+
+type LoadDurationResponse struct {
+	Points             []DurationPoint  // 降序排列的负荷持续曲线
+	PeakPower          float64          // 最大负荷 (kW)
+	AveragePower       float64          // 平均负荷 (kW)
+	MinPower           float64          // 最小负荷 (kW)
+	LoadFactor         float64          // 负荷率 = 平均负荷 / 最大负荷
+	TotalHours         float64          // 数据覆盖小时数
+	TotalEnergy        float64          // 总用电量 (kWh)
+	ThresholdHours     []ThresholdHours // 高于各阈值的小时数
+	PeakLoadingRate    float64          // 变压器最大负载率 = 最大负荷 / (变压器容量 * 功率因数)
+	AverageLoadingRate float64          // 变压器平均负载率
+}
+
+type MessageResponse struct {
+	Message string
+}
+
+type MethodAlias struct {
+	Name       string // 兼容旧版本的方法名
+	Expression string // 等价的表达式
+}
+
+type MethodInfo struct {
+	Name        string // 估计器名称
+	Description string // 说明
+	Parameter   string // 参数说明，为空表示不接受参数
+	Example     string // 写法示例
+}
+
+type MethodListResponse struct {
+	Methods []MethodInfo  // 可用的估计器；计算方法可以是单个估计器 (name 或 name:参数)，也可以是加权线性组合，例如 0.5*median+0.5*percentile:90
+	Aliases []MethodAlias // 旧方法名
+}
+
+type MonthlyBill struct {
+	Month        string     // 月份 (YYYY-MM)
+	Items        []BillItem // 分时段电度电费明细
+	Energy       float64    // 总用电量 (kWh)
+	EnergyCharge float64    // 电度电费合计 (元)
+	MaxDemand    float64    // 最大 15 分钟需量 (kW)
+	BasicCharge  float64    // 基本电费 (元)
+	Total        float64    // 电费合计 (元)
+}
+
+type OverloadSlot struct {
+	Time        string  // 时刻 (YYYY-MM-DD HH:MM:SS)
+	Load        float64 // 场站负荷 (kW)，已乘以电表倍率
+	ChargePower float64 // 储能柜充电功率 (kW)，按台数均匀充满充电时段计算
+	GridLoad    float64 // 充电时的变压器负荷 (kW)
+	Limit       float64 // 变压器允许负荷 (kW) = 变压器容量 × 功率因数 × 过载系数
+	Excess      float64 // 超出允许负荷的功率 (kW)
+}
+
+type PeriodResult struct {
+	Kind          string  // 时段类型：charge 或 discharge
+	Start         string  // 开始时间
+	End           string  // 结束时间
+	Power         float64 // 时段统计功率 (kW)
+	Hours         float64 // 时段时长 (h)
+	Amount        float64 // 充电量或放电量 (kWh)
+	CabinetEnergy float64 // 单台储能柜在该时段的可用电量 (kWh)，已计入效率、放电深度和辅助用电
+	Cabinets      float64 // 按该时段计算的储能柜台数 = 充放电量 / 单台可用电量，再按功率约束取较小值
+	Constraint    string  // 决定该时段台数的约束：energy 电量，pcs_power PCS 功率，transformer_headroom 变压器余量，load 负荷（防逆流）
+	BackflowLoss  float64 // 防逆流模式下因负荷不足而少放的电量 (kWh)，已从放电量中扣除
 }
 
 type PowerData struct {
@@ -28,6 +434,65 @@ type PowerData struct {
 	Power float64 // 功率
 }
 
+type Product struct {
+	Id                  int64
+	Name                string  // 产品型号
+	Manufacturer        string  // 生产厂家
+	EnergyCapacity      float64 // 额定能量 (kWh)
+	PcsPower            float64 // PCS 额定功率 (kW)
+	CRate               float64 // 充放电倍率 = PCS 额定功率 / 额定能量
+	RoundTripEfficiency float64 // 电池往返效率
+	PcsEfficiency       float64 // PCS 单向转换效率
+	DepthOfDischarge    float64 // 可用放电深度
+	AuxiliaryPower      float64 // 辅助用电功率 (kW)
+	Footprint           float64 // 占地面积 (m²)
+	UnitCost            float64 // 单台价格 (元)
+	CreateTime          string
+	UpdateTime          string
+}
+
+type ProductIdRequest struct {
+	Id int64 `path:"id"` // 产品 id
+}
+
+type ProductListResponse struct {
+	Products []Product
+}
+
+type ProductRank struct {
+	Product         Product
+	MinCabinetCount int     // 储能柜最小台数
+	InstalledEnergy float64 // 总额定能量 (kWh)
+	InstalledPower  float64 // 总 PCS 功率 (kW)
+	DailyDischarge  float64 // 按最小台数每天可放电量 (kWh)
+	TotalCost       float64 // 总价 (元)
+	TotalFootprint  float64 // 总占地面积 (m²)
+	CostPerKwh      float64 // 每 kWh 日放电量对应的投资 (元)，排序依据
+}
+
+type ProductRankRequest struct {
+	Capacity   CapacityConfigRequest `json:"capacity"`            // 场站测算参数，储能柜容量、效率、PCS 功率等参数取各产品的值
+	ProductIds []int64               `json:"productIds,optional"` // 参与比选的产品 id，为空时比选全部产品
+}
+
+type ProductRankResponse struct {
+	Ranks []ProductRank // 按 costPerKwh 升序排列，无法配置储能柜的产品排在最后
+}
+
+type ProductRequest struct {
+	Id                  int64   `path:"id,optional"`                   // 产品 id，仅更新时使用
+	Name                string  `json:"name"`                          // 产品型号
+	Manufacturer        string  `json:"manufacturer,optional"`         // 生产厂家
+	EnergyCapacity      float64 `json:"energyCapacity"`                // 额定能量 (kWh)
+	PcsPower            float64 `json:"pcsPower"`                      // PCS 额定功率 (kW)
+	RoundTripEfficiency float64 `json:"roundTripEfficiency,default=1"` // 电池往返效率 (0-1]
+	PcsEfficiency       float64 `json:"pcsEfficiency,default=1"`       // PCS 单向转换效率 (0-1]
+	DepthOfDischarge    float64 `json:"depthOfDischarge,default=1"`    // 可用放电深度 (0-1]
+	AuxiliaryPower      float64 `json:"auxiliaryPower,optional"`       // 辅助用电功率 (kW)
+	Footprint           float64 `json:"footprint,optional"`            // 占地面积 (m²)
+	UnitCost            float64 `json:"unitCost,optional"`             // 单台价格 (元)
+}
+
 type QueryRequest struct {
 	StartTime string `form:"startTime"` // 查询开始时间，格式：YYYY-MM-DD HH:MM:SS
 	EndTime   string `form:"endTime"`   // 查询结束时间，格式：YYYY-MM-DD HH:MM:SS
@@ -38,6 +503,232 @@ type QueryResponse struct {
 	Data []PowerData
 }
 
+type Scenario struct {
+	Id                 int64
+	Name               string      // 方案名称
+	Kind               string      // 测算类型：capacity、optimize 或 demand
+	Company            string      // 公司名称
+	CalculationMethod  string      // 计算方法，需量管理为空
+	DataStart          string      // 负荷数据开始日期或时间
+	DataEnd            string      // 负荷数据结束日期或时间
+	DataVersion        int64       // 数据版本：测算时该公司最近一次上传记录的 id，无上传记录时为 0
+	CurrentDataVersion int64       // 该公司当前最近一次上传记录的 id，与 dataVersion 不同表示测算后数据有更新
+	Author             string      // 创建人
+	SourceId           int64       // 复制来源的方案 id，不是复制的方案为 0
+	CreateTime         string      // 创建时间
+	Request            interface{} // 测算参数，与对应测算接口的请求相同，并填入按电价生成的充放电时段和实际使用的储能柜参数，重新测算时沿用，不受之后修改电价和产品的影响
+	Result             interface{} // 测算结果，与对应测算接口的返回相同
+}
+
+type ScenarioCloneRequest struct {
+	Id          int64  `path:"id"`                   // 复制来源的方案 id
+	Name        string `json:"name,optional"`        // 新方案的名称，为空时在原名称后加“（副本）”
+	Author      string `json:"author,optional"`      // 新方案的创建人，为空时沿用原方案
+	Recalculate bool   `json:"recalculate,optional"` // 是否按原参数在当前数据上重新测算，否则沿用原结果和数据版本
+}
+
+type ScenarioCompareRequest struct {
+	Left  int64 `form:"left"`  // 左侧方案 id
+	Right int64 `form:"right"` // 右侧方案 id
+}
+
+type ScenarioComparison struct {
+	Left        Scenario       // 左侧方案
+	Right       Scenario       // 右侧方案
+	InputDiffs  []ScenarioDiff // 测算参数中取值不同的字段
+	ResultDiffs []ScenarioDiff // 测算结果中取值不同的字段，超过 24 项的列表（如逐日结果）只比较条数
+}
+
+type ScenarioDiff struct {
+	Path  string // 字段路径，例如 periods[0].start
+	Left  string // 左侧方案的取值，缺少该字段时为空
+	Right string // 右侧方案的取值，缺少该字段时为空
+}
+
+type ScenarioIdRequest struct {
+	Id int64 `path:"id"` // 方案 id
+}
+
+type ScenarioListRequest struct {
+	Company string `form:"company,optional"`                               // 按公司筛选，为空时返回全部
+	Kind    string `form:"kind,optional,options=capacity|optimize|demand"` // 按测算类型筛选：capacity 容量测算，optimize 充放电时段优化，demand 需量管理
+	Author  string `form:"author,optional"`                                // 按创建人筛选
+	Limit   int    `form:"limit,default=50"`                               // 返回的最大条数
+	Offset  int    `form:"offset,optional"`                                // 跳过的条数，用于分页
+}
+
+type ScenarioListResponse struct {
+	Scenarios []ScenarioSummary // 按 id 降序排列
+}
+
+type ScenarioSummary struct {
+	Id                int64
+	Name              string // 方案名称
+	Kind              string // 测算类型：capacity、optimize 或 demand
+	Company           string // 公司名称
+	CalculationMethod string // 计算方法，需量管理为空
+	DataStart         string // 负荷数据开始日期或时间
+	DataEnd           string // 负荷数据结束日期或时间
+	DataVersion       int64  // 数据版本：测算时该公司最近一次上传记录的 id，无上传记录时为 0
+	Author            string // 创建人
+	SourceId          int64  // 复制来源的方案 id，不是复制的方案为 0
+	CreateTime        string // 创建时间
+}
+
+type SeasonCapacity struct {
+	Name              string           // 季节名称
+	Months            []int            // 适用月份
+	Periods           []CapacityPeriod // 该季节的充放电时段
+	MinCabinetCount   int              // 该季节各日台数的最小值
+	ConstrainingDay   string           // 该季节中台数最少的一天
+	LimitingPeriod    int              // 该日决定台数的时段序号
+	BindingConstraint string           // 该日决定台数的约束
+	CabinetSummary    CabinetSummary   // 该季节每日台数的分布
+	SkippedDays       []string         // 该季节中因时段内无数据而未参与测算的日期
+}
+
+type SensitivityRow struct {
+	Method            string    // 计算方法
+	MinCabinetCount   int       // 储能柜最小台数
+	LimitingPeriod    int       // 决定台数的时段序号
+	BindingConstraint string    // 决定台数的约束
+	ConstrainingDay   string    // 决定台数的日期，仅按日测算时返回
+	Amounts           []float64 // 各时段的充放电量 (kWh)，与请求中的时段一一对应
+	Cabinets          []float64 // 按各时段计算的储能柜台数
+}
+
+type SimulationRequest struct {
+	Company             string           `json:"company"`                                    // 公司名称
+	StartTime           string           `json:"startTime"`                                  // 开始时间，格式：YYYY-MM-DD HH:MM:SS
+	EndTime             string           `json:"endTime"`                                    // 结束时间，格式：YYYY-MM-DD HH:MM:SS
+	MeterMultiplier     float64          `json:"meterMultiplier,default=1"`                  // 电表倍率
+	PowerFactor         float64          `json:"powerFactor,default=1"`                      // 功率因数
+	TransformerCapacity float64          `json:"transformerCapacity,optional"`               // 变压器容量 (kW)，充电时负荷不超过变压器容量 * 功率因数，为 0 时不限制
+	Battery             BatteryConfig    `json:"battery"`                                    // 储能系统参数
+	Strategy            string           `json:"strategy,default=schedule,options=schedule"` // 调度策略：schedule 按充放电时段调度
+	Periods             []CapacityPeriod `json:"periods,optional"`                           // schedule 策略的充放电时段，使用当天时刻 (HH:MM)，结束时刻不含在内
+}
+
+type SimulationResponse struct {
+	Slots                []DispatchSlot    // 逐时刻调度结果
+	Days                 []DailyThroughput // 每日充放电量
+	TotalChargeEnergy    float64           // 总充电量 (kWh)
+	TotalDischargeEnergy float64           // 总放电量 (kWh)
+	EquivalentCycles     float64           // 总等效循环次数
+}
+
+type SizingCurveRequest struct {
+	Company             string        `json:"company"`                      // 公司名称
+	StartTime           string        `json:"startTime"`                    // 用于模拟调度的负荷数据开始时间，格式：YYYY-MM-DD HH:MM:SS，收益按覆盖天数折算为全年
+	EndTime             string        `json:"endTime"`                      // 负荷数据结束时间，格式：YYYY-MM-DD HH:MM:SS
+	MeterMultiplier     float64       `json:"meterMultiplier,default=1"`    // 电表倍率
+	PowerFactor         float64       `json:"powerFactor,default=1"`        // 功率因数
+	TransformerCapacity float64       `json:"transformerCapacity,optional"` // 变压器容量 (kW)，充电时负荷不超过变压器容量 * 功率因数，为 0 时不限制
+	TariffId            int64         `json:"tariffId"`                     // 分时电价 id
+	ProductId           int64         `json:"productId"`                    // 储能柜产品 id
+	Cabinet             CabinetParams `json:"cabinet,optional"`             // 覆盖产品参数的储能柜参数，与 /capacity/ 的同名参数相同
+	MaxCabinetCount     int           `json:"maxCabinetCount"`              // 评估的最大台数 N，从 1 台评估到 N 台
+	UnitCost            float64       `json:"unitCost,optional"`            // 单台储能柜投资 (元)，为 0 时取产品单价
+	FixedCost           float64       `json:"fixedCost,optional"`           // 与台数无关的固定投资 (元)，例如并网和土建
+	OmCostPerCabinet    float64       `json:"omCostPerCabinet,optional"`    // 单台储能柜年运维费用 (元)
+	LifeYears           int           `json:"lifeYears,default=10"`         // 运行年限
+	DiscountRate        float64       `json:"discountRate,default=0.08"`    // 折现率，需大于 -1
+	DegradationRate     float64       `json:"degradationRate,default=0.02"` // 年衰减率
+}
+
+type SizingCurveResponse struct {
+	SimulatedDays int           // 模拟调度覆盖的天数
+	Points        []SizingPoint // 1 到 maxCabinetCount 台的评估结果
+	BestNpvCount  int           // 净现值最大的台数
+	BestNpv       float64       // 最大净现值 (元)
+}
+
+type SizingPoint struct {
+	CabinetCount          int     // 储能柜台数
+	BatteryEnergy         float64 // 储能系统额定能量 (kWh)
+	AnnualDischargeEnergy float64 // 年放电量 (kWh)
+	Utilization           float64 // 利用率 = 年放电量 / (可用容量 × 365)，即平均每天的等效满充满放次数
+	AnnualRevenue         float64 // 年峰谷套利收益 (元)
+	MarginalRevenue       float64 // 比少一台时增加的年收益 (元)
+	CapitalCost           float64 // 初始投资 (元)
+	SimplePayback         float64 // 静态投资回收期 (年)，无法回本时为 0
+	Npv                   float64 // 净现值 (元)
+	Irr                   float64 // 内部收益率
+	IrrAvailable          bool    // 是否存在内部收益率
+}
+
+type Tariff struct {
+	Id            int64
+	Name          string         // 电价名称
+	Province      string         // 省份
+	VoltageLevel  string         // 电压等级
+	EffectiveFrom string         // 生效日期 (YYYY-MM-DD)
+	EffectiveTo   string         // 失效日期 (YYYY-MM-DD)，为空表示长期有效
+	Description   string         // 说明
+	DemandPrice   float64        // 需量电价 (元/kW·月)
+	CapacityPrice float64        // 容量电价 (元/kVA·月)
+	Periods       []TariffPeriod // 各时段
+	CreateTime    string
+	UpdateTime    string
+}
+
+type TariffIdRequest struct {
+	Id int64 `path:"id"` // 电价 id
+}
+
+type TariffListRequest struct {
+	Province string `form:"province,optional"` // 按省份筛选，为空时返回全部
+}
+
+type TariffListResponse struct {
+	Tariffs []Tariff
+}
+
+type TariffPeriod struct {
+	Kind   string  `json:"kind,options=critical|peak|flat|valley"` // 时段类型：critical 尖峰，peak 高峰，flat 平段，valley 低谷
+	Months []int   `json:"months,optional"`                        // 适用月份 (1-12)，为空表示全年
+	Start  string  `json:"start"`                                  // 开始时刻 (HH:MM)，包含在内
+	End    string  `json:"end"`                                    // 结束时刻 (HH:MM)，不含在内；早于开始时刻表示跨零点，例如 22:00 至次日 08:00；与开始时刻相同表示全天，例如 00:00 至 00:00
+	Price  float64 `json:"price"`                                  // 电度电价 (元/kWh)
+}
+
+type TariffRequest struct {
+	Id            int64          `path:"id,optional"`            // 电价 id，仅更新时使用
+	Name          string         `json:"name"`                   // 电价名称
+	Province      string         `json:"province"`               // 省份
+	VoltageLevel  string         `json:"voltageLevel,optional"`  // 电压等级，例如 1-10kV
+	EffectiveFrom string         `json:"effectiveFrom"`          // 生效日期 (YYYY-MM-DD)
+	EffectiveTo   string         `json:"effectiveTo,optional"`   // 失效日期 (YYYY-MM-DD)，为空表示长期有效
+	Description   string         `json:"description,optional"`   // 说明
+	DemandPrice   float64        `json:"demandPrice,optional"`   // 需量电价 (元/kW·月)，按每月最大需量计收基本电费
+	CapacityPrice float64        `json:"capacityPrice,optional"` // 容量电价 (元/kVA·月)，按变压器容量计收基本电费
+	Periods       []TariffPeriod `json:"periods"`                // 各时段，每个月的时段需覆盖全天且互不重叠
+}
+
+type ThresholdHours struct {
+	Threshold float64 // 功率阈值 (kW)
+	Hours     float64 // 高于阈值的小时数
+	Percent   float64 // 高于阈值的时间占比 (%)
+}
+
+type TypicalCurve struct {
+	Group    string       // 分组名称：all、weekday、weekend 或月份 (YYYY-MM)
+	DayCount int          // 分组内的天数
+	Points   []CurvePoint // 96 个时刻的统计结果
+}
+
+type TypicalCurveRequest struct {
+	Company   string `form:"company"`                                         // 公司名称
+	StartTime string `form:"startTime"`                                       // 开始时间，格式：YYYY-MM-DD HH:MM:SS
+	EndTime   string `form:"endTime"`                                         // 结束时间，格式：YYYY-MM-DD HH:MM:SS
+	Method    string `form:"method,default=average"`                          // 统计方法，与容量计算的 calculationMethod 相同
+	GroupBy   string `form:"groupBy,default=none,options=none|weekday|month"` // 分组方式：none 不分组，weekday 工作日/周末，month 按月
+}
+
+type TypicalCurveResponse struct {
+	Curves []TypicalCurve
+}
+
 type UploadRequest struct {
 	File    string `form:"file"`    // 文件内容作为Base64字符串上传
 	Company string `form:"company"` // 公司名称
@@ -46,3 +737,21 @@ type UploadRequest struct {
 type UploadResponse struct {
 	Message string
 }
+
+type WindowOptimizationResponse struct {
+	Optimal             WindowSchedule // 日均套利收益最高的充放电时段及台数
+	TariffDefault       WindowSchedule // 按电价直接生成的时段：低谷充电，高峰和尖峰放电
+	UserSupplied        WindowSchedule // 请求中填写的时段，未填写时为空
+	Improvement         float64        // 最优时段比用户时段（未填写时比电价时段）每天多出的收益 (元)
+	EvaluatedCandidates int            // 评估过的候选时段组合数，最多 400 组，达到后返回已找到的最优时段
+	ScenarioId          int64          // 保存的测算方案 id，保存失败时为 0
+}
+
+type WindowSchedule struct {
+	Periods              []CapacityPeriod // 充放电时段
+	CabinetCount         int              // 按该组时段测算的储能柜最小台数
+	BindingConstraint    string           // 决定台数的约束，与 CapacityConfigResponse 相同
+	DailyValue           float64          // 按该台数和时段调度的日均峰谷套利收益 (元)
+	DailyChargeEnergy    float64          // 日均充电量 (kWh)
+	DailyDischargeEnergy float64          // 日均放电量 (kWh)
+}
@@ -0,0 +1,3 @@
+-- 为按旧版 tariff.sql 建表的数据库增加需量电价，新建数据库直接执行 tariff.sql 即可
+ALTER TABLE `tariff`
+  ADD COLUMN `demand_price` double NOT NULL DEFAULT 0 COMMENT '需量电价 (元/kW·月)，按每月最大需量计收基本电费' AFTER `description`;
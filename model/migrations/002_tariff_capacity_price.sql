@@ -0,0 +1,3 @@
+-- 为已执行 001_tariff_demand_price.sql 的数据库增加容量电价，新建数据库直接执行 tariff.sql 即可
+ALTER TABLE `tariff`
+  ADD COLUMN `capacity_price` double NOT NULL DEFAULT 0 COMMENT '容量电价 (元/kVA·月)，按变压器容量计收基本电费' AFTER `demand_price`;
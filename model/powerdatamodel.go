@@ -12,6 +12,30 @@ import (
 type PowerDataModel interface {
 	Insert(ctx context.Context, data *PowerData) (sql.Result, error)
 	QueryData(ctx context.Context, startTime, endTime time.Time, company string) ([]PowerData, error)
+	QueryCompanySummaries(ctx context.Context) ([]CompanySummary, error)
+	QueryDailyCounts(ctx context.Context, company string) ([]DailyCount, error)
+	QueryAllDailyCounts(ctx context.Context) ([]CompanyDailyCount, error)
+}
+
+// CompanySummary 每个公司的数据范围和条数
+type CompanySummary struct {
+	Company   string    `db:"company"`
+	FirstTime time.Time `db:"first_time"`
+	LastTime  time.Time `db:"last_time"`
+	RowCount  int64     `db:"row_count"`
+}
+
+// DailyCount 每天不重复的数据时刻数量，重复上传的数据只计一次
+type DailyCount struct {
+	DataDate  time.Time `db:"data_date"`
+	SlotCount int64     `db:"slot_count"`
+}
+
+// CompanyDailyCount 某公司某天不重复的数据时刻数量
+type CompanyDailyCount struct {
+	Company   string    `db:"company"`
+	DataDate  time.Time `db:"data_date"`
+	SlotCount int64     `db:"slot_count"`
 }
 
 // NewPowerDataModel 创建一个新的 PowerDataModel 实例
@@ -32,3 +56,36 @@ func (m *defaultPowerDataModel) QueryData(ctx context.Context, startTime, endTim
 	}
 	return data, nil
 }
+
+// QueryCompanySummaries 方法用于查询所有公司的数据起止时间和条数
+func (m *defaultPowerDataModel) QueryCompanySummaries(ctx context.Context) ([]CompanySummary, error) {
+	query := `SELECT company, MIN(data_time) AS first_time, MAX(data_time) AS last_time, COUNT(*) AS row_count FROM ` + m.table + ` GROUP BY company ORDER BY company ASC`
+	var data []CompanySummary
+	err := m.conn.QueryRowsCtx(ctx, &data, query)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// QueryDailyCounts 方法用于查询某公司每天的数据时刻数量
+func (m *defaultPowerDataModel) QueryDailyCounts(ctx context.Context, company string) ([]DailyCount, error) {
+	query := `SELECT DATE(data_time) AS data_date, COUNT(DISTINCT data_time) AS slot_count FROM ` + m.table + ` WHERE company = ? GROUP BY DATE(data_time) ORDER BY data_date ASC`
+	var data []DailyCount
+	err := m.conn.QueryRowsCtx(ctx, &data, query, company)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// QueryAllDailyCounts 方法用于一次查询所有公司每天的数据时刻数量，按公司和日期升序排列
+func (m *defaultPowerDataModel) QueryAllDailyCounts(ctx context.Context) ([]CompanyDailyCount, error) {
+	query := `SELECT company, DATE(data_time) AS data_date, COUNT(DISTINCT data_time) AS slot_count FROM ` + m.table + ` GROUP BY company, DATE(data_time) ORDER BY company ASC, data_date ASC`
+	var data []CompanyDailyCount
+	err := m.conn.QueryRowsCtx(ctx, &data, query)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,17 @@
+CREATE TABLE `scenario` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL DEFAULT '' COMMENT '方案名称',
+  `kind` varchar(32) NOT NULL COMMENT '测算类型：capacity 容量测算，optimize 充放电时段优化，demand 需量管理',
+  `company` varchar(255) NOT NULL COMMENT '公司名称',
+  `calculation_method` varchar(64) NOT NULL DEFAULT '' COMMENT '计算方法，需量管理为空',
+  `data_start` varchar(19) NOT NULL DEFAULT '' COMMENT '负荷数据开始日期或时间',
+  `data_end` varchar(19) NOT NULL DEFAULT '' COMMENT '负荷数据结束日期或时间',
+  `data_version` bigint NOT NULL DEFAULT 0 COMMENT '数据版本：测算时该公司最近一次上传记录的 id，无上传记录时为 0',
+  `request` mediumtext NOT NULL COMMENT '测算参数 (JSON)',
+  `result` mediumtext NOT NULL COMMENT '测算结果 (JSON)',
+  `author` varchar(64) NOT NULL DEFAULT '' COMMENT '创建人',
+  `source_id` bigint NOT NULL DEFAULT 0 COMMENT '复制来源的方案 id，不是复制的方案为 0',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_company` (`company`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='测算方案';
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"github.com/zeromicro/go-zero/core/stringx"
+)
+
+// 列表查询的列，不含测算参数和结果
+var scenarioSummaryRows = strings.Join(stringx.Remove(scenarioFieldNames, "`request`", "`result`"), ",")
+
+// ScenarioModel 接口，保存每次测算的参数和结果，用于复现和对比
+type ScenarioModel interface {
+	Insert(ctx context.Context, data *Scenario) (sql.Result, error)
+	FindOne(ctx context.Context, id int64) (*Scenario, error)
+	FindAll(ctx context.Context, filter ScenarioFilter) ([]Scenario, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// ScenarioFilter 列表查询条件，字符串为空时不按该字段筛选
+type ScenarioFilter struct {
+	Company string
+	Kind    string
+	Author  string
+	Limit   int
+	Offset  int
+}
+
+// NewScenarioModel 创建一个新的 ScenarioModel 实例
+func NewScenarioModel(conn sqlx.SqlConn) ScenarioModel {
+	return newScenarioModel(conn)
+}
+
+// FindAll 按条件查询方案，不含测算参数和结果，按 id 降序排列
+func (m *defaultScenarioModel) FindAll(ctx context.Context, filter ScenarioFilter) ([]Scenario, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Company != "" {
+		conditions = append(conditions, `company = ?`)
+		args = append(args, filter.Company)
+	}
+	if filter.Kind != "" {
+		conditions = append(conditions, `kind = ?`)
+		args = append(args, filter.Kind)
+	}
+	if filter.Author != "" {
+		conditions = append(conditions, `author = ?`)
+		args = append(args, filter.Author)
+	}
+
+	query := `SELECT ` + scenarioSummaryRows + ` FROM ` + m.table
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+	query += ` ORDER BY id DESC LIMIT ? OFFSET ?`
+	args = append(args, filter.Limit, filter.Offset)
+
+	var data []Scenario
+	err := m.conn.QueryRowsPartialCtx(ctx, &data, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,23 @@
+CREATE TABLE `storage_product` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL COMMENT '产品型号',
+  `manufacturer` varchar(255) NOT NULL DEFAULT '' COMMENT '生产厂家',
+  `energy_capacity` double NOT NULL COMMENT '额定能量 (kWh)',
+  `pcs_power` double NOT NULL COMMENT 'PCS 额定功率 (kW)',
+  `round_trip_efficiency` double NOT NULL DEFAULT 1 COMMENT '电池往返效率 (0-1)',
+  `pcs_efficiency` double NOT NULL DEFAULT 1 COMMENT 'PCS 单向转换效率 (0-1)',
+  `depth_of_discharge` double NOT NULL DEFAULT 1 COMMENT '可用放电深度 (0-1)',
+  `auxiliary_power` double NOT NULL DEFAULT 0 COMMENT '辅助用电功率 (kW)',
+  `footprint` double NOT NULL DEFAULT 0 COMMENT '占地面积 (m²)',
+  `unit_cost` double NOT NULL DEFAULT 0 COMMENT '单台价格 (元)',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_name` (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='储能柜产品目录';
+
+-- 示例产品，参数以厂家规格书为准
+INSERT INTO `storage_product` (`name`, `manufacturer`, `energy_capacity`, `pcs_power`, `round_trip_efficiency`, `pcs_efficiency`, `depth_of_discharge`, `auxiliary_power`, `footprint`, `unit_cost`) VALUES
+('100kW/215kWh 一体柜', '示例厂家', 215, 100, 0.92, 0.97, 0.9, 1.5, 2.2, 260000),
+('125kW/261kWh 一体柜', '示例厂家', 261, 125, 0.92, 0.97, 0.9, 1.8, 2.6, 310000),
+('100kW/430kWh 一体柜', '示例厂家', 430, 100, 0.93, 0.97, 0.9, 2.0, 3.0, 480000);
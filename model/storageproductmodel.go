@@ -0,0 +1,34 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// StorageProductModel 接口，储能柜产品目录的增删改查
+type StorageProductModel interface {
+	Insert(ctx context.Context, data *StorageProduct) (sql.Result, error)
+	FindOne(ctx context.Context, id int64) (*StorageProduct, error)
+	FindOneByName(ctx context.Context, name string) (*StorageProduct, error)
+	FindAll(ctx context.Context) ([]StorageProduct, error)
+	Update(ctx context.Context, data *StorageProduct) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// NewStorageProductModel 创建一个新的 StorageProductModel 实例
+func NewStorageProductModel(conn sqlx.SqlConn) StorageProductModel {
+	return newStorageProductModel(conn)
+}
+
+// FindAll 查询全部产品，按 id 升序排列
+func (m *defaultStorageProductModel) FindAll(ctx context.Context) ([]StorageProduct, error) {
+	query := `SELECT ` + storageProductRows + ` FROM ` + m.table + ` ORDER BY id ASC`
+	var data []StorageProduct
+	err := m.conn.QueryRowsCtx(ctx, &data, query)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
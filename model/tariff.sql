@@ -0,0 +1,44 @@
+CREATE TABLE `tariff` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL COMMENT '电价名称',
+  `province` varchar(64) NOT NULL COMMENT '省份',
+  `voltage_level` varchar(64) NOT NULL DEFAULT '' COMMENT '电压等级，例如 1-10kV',
+  `effective_from` date NOT NULL COMMENT '生效日期',
+  `effective_to` date NULL COMMENT '失效日期，为空表示长期有效',
+  `description` varchar(1024) NOT NULL DEFAULT '' COMMENT '说明',
+  `demand_price` double NOT NULL DEFAULT 0 COMMENT '需量电价 (元/kW·月)，按每月最大需量计收基本电费',
+  `capacity_price` double NOT NULL DEFAULT 0 COMMENT '容量电价 (元/kVA·月)，按变压器容量计收基本电费',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_province` (`province`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='分时电价';
+
+CREATE TABLE `tariff_period` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `tariff_id` bigint NOT NULL COMMENT '所属电价 id',
+  `kind` varchar(16) NOT NULL COMMENT '时段类型：critical 尖峰，peak 高峰，flat 平段，valley 低谷',
+  `months` varchar(64) NOT NULL DEFAULT '' COMMENT '适用月份，逗号分隔，例如 1,7,8,12，为空表示全年',
+  `start_time` varchar(5) NOT NULL COMMENT '开始时刻 (HH:MM)，包含在内',
+  `end_time` varchar(5) NOT NULL COMMENT '结束时刻 (HH:MM)，不含在内，早于开始时刻表示跨零点',
+  `price` double NOT NULL COMMENT '电度电价 (元/kWh)',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_tariff_id` (`tariff_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='分时电价时段';
+
+-- 示例：浙江省大工业用电分时电价，时段划分参照浙江省现行政策，电价仅作示意，使用前请按最新电价文件核对
+INSERT INTO `tariff` (`id`, `name`, `province`, `voltage_level`, `effective_from`, `description`, `demand_price`, `capacity_price`) VALUES
+(1, '浙江省大工业用电（示例）', '浙江', '1-10kV', '2023-01-01', '尖峰时段仅在 1、7、8、12 月执行，其余月份按高峰计价；电价为示意值', 40, 30);
+
+INSERT INTO `tariff_period` (`tariff_id`, `kind`, `months`, `start_time`, `end_time`, `price`) VALUES
+(1, 'valley', '', '22:00', '08:00', 0.32),
+(1, 'valley', '', '11:00', '13:00', 0.32),
+(1, 'peak', '', '08:00', '09:00', 1.05),
+(1, 'peak', '', '13:00', '15:00', 1.05),
+(1, 'peak', '', '17:00', '22:00', 1.05),
+(1, 'critical', '1,7,8,12', '09:00', '11:00', 1.30),
+(1, 'critical', '1,7,8,12', '15:00', '17:00', 1.30),
+(1, 'peak', '2,3,4,5,6,9,10,11', '09:00', '11:00', 1.05),
+(1, 'peak', '2,3,4,5,6,9,10,11', '15:00', '17:00', 1.05);
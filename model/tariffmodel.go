@@ -0,0 +1,97 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// TariffModel 接口，分时电价及其时段的增删改查，电价和时段在同一事务中写入
+type TariffModel interface {
+	Insert(ctx context.Context, data *Tariff) (sql.Result, error)
+	FindOne(ctx context.Context, id int64) (*Tariff, error)
+	FindAll(ctx context.Context, province string) ([]Tariff, error)
+	Update(ctx context.Context, data *Tariff) error
+	Delete(ctx context.Context, id int64) error
+	InsertWithPeriods(ctx context.Context, data *Tariff, periods []TariffPeriod) (int64, error)
+	UpdateWithPeriods(ctx context.Context, data *Tariff, periods []TariffPeriod) error
+	DeleteWithPeriods(ctx context.Context, id int64) error
+}
+
+// NewTariffModel 创建一个新的 TariffModel 实例
+func NewTariffModel(conn sqlx.SqlConn) TariffModel {
+	return newTariffModel(conn)
+}
+
+// FindAll 查询电价，province 不为空时只查询该省份，按 id 升序排列
+func (m *defaultTariffModel) FindAll(ctx context.Context, province string) ([]Tariff, error) {
+	query := `SELECT ` + tariffRows + ` FROM ` + m.table
+	var args []interface{}
+	if province != "" {
+		query += ` WHERE province = ?`
+		args = append(args, province)
+	}
+	query += ` ORDER BY id ASC`
+	var data []Tariff
+	err := m.conn.QueryRowsCtx(ctx, &data, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// InsertWithPeriods 插入电价及其时段，返回新电价的 id
+func (m *defaultTariffModel) InsertWithPeriods(ctx context.Context, data *Tariff, periods []TariffPeriod) (int64, error) {
+	var id int64
+	err := m.conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		query := `INSERT INTO ` + m.table + ` (` + tariffRowsExpectAutoSet + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		result, err := session.ExecCtx(ctx, query, data.Name, data.Province, data.VoltageLevel, data.EffectiveFrom, data.EffectiveTo, data.Description, data.DemandPrice, data.CapacityPrice)
+		if err != nil {
+			return err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		return insertTariffPeriods(ctx, session, id, periods)
+	})
+	return id, err
+}
+
+// UpdateWithPeriods 更新电价，并用 periods 替换原有的全部时段
+func (m *defaultTariffModel) UpdateWithPeriods(ctx context.Context, data *Tariff, periods []TariffPeriod) error {
+	return m.conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		query := `UPDATE ` + m.table + ` SET ` + tariffRowsWithPlaceHolder + ` WHERE id = ?`
+		_, err := session.ExecCtx(ctx, query, data.Name, data.Province, data.VoltageLevel, data.EffectiveFrom, data.EffectiveTo, data.Description, data.DemandPrice, data.CapacityPrice, data.Id)
+		if err != nil {
+			return err
+		}
+		if _, err := session.ExecCtx(ctx, `DELETE FROM `+"`tariff_period`"+` WHERE tariff_id = ?`, data.Id); err != nil {
+			return err
+		}
+		return insertTariffPeriods(ctx, session, data.Id, periods)
+	})
+}
+
+// DeleteWithPeriods 删除电价及其时段
+func (m *defaultTariffModel) DeleteWithPeriods(ctx context.Context, id int64) error {
+	return m.conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		if _, err := session.ExecCtx(ctx, `DELETE FROM `+"`tariff_period`"+` WHERE tariff_id = ?`, id); err != nil {
+			return err
+		}
+		_, err := session.ExecCtx(ctx, `DELETE FROM `+m.table+` WHERE id = ?`, id)
+		return err
+	})
+}
+
+// 在事务中写入电价的时段
+func insertTariffPeriods(ctx context.Context, session sqlx.Session, tariffId int64, periods []TariffPeriod) error {
+	query := "INSERT INTO `tariff_period` (" + tariffPeriodRowsExpectAutoSet + ") VALUES (?, ?, ?, ?, ?, ?)"
+	for _, p := range periods {
+		if _, err := session.ExecCtx(ctx, query, tariffId, p.Kind, p.Months, p.StartTime, p.EndTime, p.Price); err != nil {
+			return err
+		}
+	}
+	return nil
+}
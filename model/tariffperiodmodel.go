@@ -0,0 +1,33 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// TariffPeriodModel 接口，分时电价的时段
+type TariffPeriodModel interface {
+	Insert(ctx context.Context, data *TariffPeriod) (sql.Result, error)
+	FindOne(ctx context.Context, id int64) (*TariffPeriod, error)
+	FindByTariffId(ctx context.Context, tariffId int64) ([]TariffPeriod, error)
+	Update(ctx context.Context, data *TariffPeriod) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// NewTariffPeriodModel 创建一个新的 TariffPeriodModel 实例
+func NewTariffPeriodModel(conn sqlx.SqlConn) TariffPeriodModel {
+	return newTariffPeriodModel(conn)
+}
+
+// FindByTariffId 查询电价的全部时段，按 id 升序排列
+func (m *defaultTariffPeriodModel) FindByTariffId(ctx context.Context, tariffId int64) ([]TariffPeriod, error) {
+	query := `SELECT ` + tariffPeriodRows + ` FROM ` + m.table + ` WHERE tariff_id = ? ORDER BY id ASC`
+	var data []TariffPeriod
+	err := m.conn.QueryRowsCtx(ctx, &data, query, tariffId)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,10 @@
+CREATE TABLE `upload_log` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `company` varchar(255) NOT NULL COMMENT '公司名称',
+  `file_name` varchar(255) NOT NULL COMMENT '上传的文件名',
+  `row_count` int NOT NULL DEFAULT 0 COMMENT '清洗后入库的数据条数',
+  `day_count` int NOT NULL DEFAULT 0 COMMENT '清洗后入库的天数',
+  `create_time` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '上传时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_company` (`company`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='数据上传记录';
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// UploadLogModel 接口，记录每次上传清洗入库的结果
+type UploadLogModel interface {
+	Insert(ctx context.Context, data *UploadLog) (sql.Result, error)
+	FindLatestPerCompany(ctx context.Context) ([]UploadLog, error)
+	FindLatestByCompany(ctx context.Context, company string) (*UploadLog, error)
+}
+
+// NewUploadLogModel 创建一个新的 UploadLogModel 实例
+func NewUploadLogModel(conn sqlx.SqlConn) UploadLogModel {
+	return newUploadLogModel(conn)
+}
+
+// FindLatestPerCompany 查询每个公司最近一次的上传记录
+func (m *defaultUploadLogModel) FindLatestPerCompany(ctx context.Context) ([]UploadLog, error) {
+	query := `SELECT ` + uploadLogRows + ` FROM ` + m.table + ` WHERE id IN (SELECT MAX(id) FROM ` + m.table + ` GROUP BY company)`
+	var data []UploadLog
+	err := m.conn.QueryRowsCtx(ctx, &data, query)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// FindLatestByCompany 查询公司最近一次的上传记录，无记录时返回 ErrNotFound
+func (m *defaultUploadLogModel) FindLatestByCompany(ctx context.Context, company string) (*UploadLog, error) {
+	query := `SELECT ` + uploadLogRows + ` FROM ` + m.table + ` WHERE company = ? ORDER BY id DESC LIMIT 1`
+	var resp UploadLog
+	err := m.conn.QueryRowCtx(ctx, &resp, query, company)
+	switch err {
+	case nil:
+		return &resp, nil
+	case sqlx.ErrNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, err
+	}
+}